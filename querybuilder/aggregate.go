@@ -0,0 +1,99 @@
+package querybuilder
+
+// AggregateExpr is an aggregate function call such as COUNT(id) that can be
+// selected (optionally aliased) or compared in HAVING conditions
+type AggregateExpr struct {
+	dialect Dialect
+	expr    string
+	alias   string
+}
+
+// Count creates a COUNT(column) expression; an empty column or "*" counts rows
+func (qb *QueryBuilder) Count(column string) AggregateExpr {
+	if column == "" {
+		column = "*"
+	}
+	return qb.aggregate("COUNT", column, false)
+}
+
+// CountDistinct creates a COUNT(DISTINCT column) expression
+func (qb *QueryBuilder) CountDistinct(column string) AggregateExpr {
+	return qb.aggregate("COUNT", column, true)
+}
+
+// Sum creates a SUM(column) expression
+func (qb *QueryBuilder) Sum(column string) AggregateExpr {
+	return qb.aggregate("SUM", column, false)
+}
+
+// Avg creates an AVG(column) expression
+func (qb *QueryBuilder) Avg(column string) AggregateExpr {
+	return qb.aggregate("AVG", column, false)
+}
+
+// Min creates a MIN(column) expression
+func (qb *QueryBuilder) Min(column string) AggregateExpr {
+	return qb.aggregate("MIN", column, false)
+}
+
+// Max creates a MAX(column) expression
+func (qb *QueryBuilder) Max(column string) AggregateExpr {
+	return qb.aggregate("MAX", column, false)
+}
+
+func (qb *QueryBuilder) aggregate(function, column string, distinct bool) AggregateExpr {
+	arg := escapeColumnRef(qb.dialect, column)
+	if distinct {
+		arg = "DISTINCT " + arg
+	}
+	return AggregateExpr{dialect: qb.dialect, expr: function + "(" + arg + ")"}
+}
+
+// As returns a copy of the expression with the given alias
+func (a AggregateExpr) As(alias string) AggregateExpr {
+	a.alias = alias
+	return a
+}
+
+// String returns the expression SQL including its alias
+func (a AggregateExpr) String() string {
+	if a.alias == "" {
+		return a.expr
+	}
+	return a.expr + " AS " + escapeIdentifier(a.dialect, a.alias)
+}
+
+// ToSQL generates the expression SQL so it can be added to a select list
+func (a AggregateExpr) ToSQL() (string, []any, error) {
+	return a.String(), nil, nil
+}
+
+// Eq creates an equality condition on the aggregate
+func (a AggregateExpr) Eq(value any) NegatableCondition {
+	return newCondition(a.expr, Equal, value, "value")
+}
+
+// NotEq creates an inequality condition on the aggregate
+func (a AggregateExpr) NotEq(value any) NegatableCondition {
+	return newCondition(a.expr, NotEqual, value, "value")
+}
+
+// Gt creates a greater-than condition on the aggregate
+func (a AggregateExpr) Gt(value any) NegatableCondition {
+	return newCondition(a.expr, GreatThan, value, "value")
+}
+
+// GtOrEq creates a greater-than-or-equal condition on the aggregate
+func (a AggregateExpr) GtOrEq(value any) NegatableCondition {
+	return newCondition(a.expr, GreatThanOrEqual, value, "value")
+}
+
+// Lt creates a less-than condition on the aggregate
+func (a AggregateExpr) Lt(value any) NegatableCondition {
+	return newCondition(a.expr, LessTnan, value, "value")
+}
+
+// LtOrEq creates a less-than-or-equal condition on the aggregate
+func (a AggregateExpr) LtOrEq(value any) NegatableCondition {
+	return newCondition(a.expr, LessThanOrEqual, value, "value")
+}
@@ -0,0 +1,169 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// AlterTableBuilder builds ALTER TABLE statements
+type AlterTableBuilder interface {
+	AddColumn(name, definition string) AlterTableBuilder
+	DropColumn(name string) AlterTableBuilder
+	AlterColumn(name, dataType string, nullable bool) AlterTableBuilder
+	AddConstraint(name string, constraint Constraint) AlterTableBuilder
+	DropConstraint(name string) AlterTableBuilder
+	ToSQL() (string, []any, error)
+}
+
+// alterTableBuilder implements AlterTableBuilder
+type alterTableBuilder struct {
+	dialect Dialect
+	table   string
+	actions []alterAction
+}
+
+// alterAction is one change made by an ALTER TABLE statement
+type alterAction struct {
+	kind       string // ADD COLUMN, DROP COLUMN, ALTER COLUMN, ADD CONSTRAINT or DROP CONSTRAINT
+	name       string
+	definition string // column definition, or data type for ALTER COLUMN
+	nullable   bool
+	constraint Constraint
+}
+
+// AlterTable begins an ALTER TABLE statement. PostgreSQL and MySQL apply
+// several changes in one statement; the other dialects take one at a time,
+// and SQLite only adds and drops columns.
+func (qb *QueryBuilder) AlterTable(table string) AlterTableBuilder {
+	return &alterTableBuilder{
+		dialect: qb.dialect,
+		table:   table,
+	}
+}
+
+// AddColumn adds a column; definition is its type followed by any
+// DEFAULT and NOT NULL, e.g. "VARCHAR(255) DEFAULT 'n/a' NOT NULL"
+func (ab *alterTableBuilder) AddColumn(name, definition string) AlterTableBuilder {
+	ab.actions = append(ab.actions, alterAction{kind: "ADD COLUMN", name: name, definition: definition})
+	return ab
+}
+
+// DropColumn drops a column
+func (ab *alterTableBuilder) DropColumn(name string) AlterTableBuilder {
+	ab.actions = append(ab.actions, alterAction{kind: "DROP COLUMN", name: name})
+	return ab
+}
+
+// AlterColumn changes the data type and nullability of a column
+func (ab *alterTableBuilder) AlterColumn(name, dataType string, nullable bool) AlterTableBuilder {
+	ab.actions = append(ab.actions, alterAction{kind: "ALTER COLUMN", name: name, definition: dataType, nullable: nullable})
+	return ab
+}
+
+// AddConstraint adds a named constraint such as
+// ForeignKey("customer_id").References("customers", "id").OnDelete(Cascade)
+func (ab *alterTableBuilder) AddConstraint(name string, constraint Constraint) AlterTableBuilder {
+	ab.actions = append(ab.actions, alterAction{kind: "ADD CONSTRAINT", name: name, constraint: constraint})
+	return ab
+}
+
+// DropConstraint drops a named constraint
+func (ab *alterTableBuilder) DropConstraint(name string) AlterTableBuilder {
+	ab.actions = append(ab.actions, alterAction{kind: "DROP CONSTRAINT", name: name})
+	return ab
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (ab *alterTableBuilder) ToSQL() (string, []any, error) {
+	if ab.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+	if len(ab.actions) == 0 {
+		return "", nil, errors.New("no table changes specified")
+	}
+	switch ab.dialect.(type) {
+	case postgresDialect, mysqlDialect:
+	case sqlserverDialect, oracleDialect:
+		if len(ab.actions) > 1 {
+			return "", nil, errors.New("this dialect applies one ALTER TABLE change per statement")
+		}
+	default:
+		if len(ab.actions) > 1 {
+			return "", nil, errors.New("this dialect applies one ALTER TABLE change per statement")
+		}
+		if kind := ab.actions[0].kind; kind != "ADD COLUMN" && kind != "DROP COLUMN" {
+			return "", nil, errors.New("SQLite can only add or drop columns of an existing table")
+		}
+	}
+
+	var query strings.Builder
+	query.WriteString("ALTER TABLE " + escapeTableRef(ab.dialect, ab.table) + " ")
+	for i, action := range ab.actions {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		actionSQL, err := ab.actionSQL(action)
+		if err != nil {
+			return "", nil, err
+		}
+		query.WriteString(actionSQL)
+	}
+	return query.String(), nil, nil
+}
+
+// actionSQL renders one change in the dialect's syntax
+func (ab *alterTableBuilder) actionSQL(action alterAction) (string, error) {
+	if action.name == "" {
+		return "", errors.New("no column or constraint name specified")
+	}
+
+	switch action.kind {
+	case "ADD COLUMN":
+		if action.definition == "" {
+			return "", errors.New("no definition specified for column " + action.name)
+		}
+		switch ab.dialect.(type) {
+		case sqlserverDialect:
+			return "ADD " + action.name + " " + action.definition, nil
+		case oracleDialect:
+			return "ADD (" + action.name + " " + action.definition + ")", nil
+		}
+		return "ADD COLUMN " + action.name + " " + action.definition, nil
+
+	case "ALTER COLUMN":
+		if action.definition == "" {
+			return "", errors.New("no data type specified for column " + action.name)
+		}
+		null := " NOT NULL"
+		if action.nullable {
+			null = " NULL"
+		}
+		switch ab.dialect.(type) {
+		case postgresDialect:
+			setNull := " SET NOT NULL"
+			if action.nullable {
+				setNull = " DROP NOT NULL"
+			}
+			return "ALTER COLUMN " + action.name + " TYPE " + action.definition +
+				", ALTER COLUMN " + action.name + setNull, nil
+		case mysqlDialect:
+			return "MODIFY COLUMN " + action.name + " " + action.definition + null, nil
+		case oracleDialect:
+			return "MODIFY (" + action.name + " " + action.definition + null + ")", nil
+		}
+		return "ALTER COLUMN " + action.name + " " + action.definition + null, nil
+
+	case "ADD CONSTRAINT":
+		if action.constraint == nil {
+			return "", errors.New("no constraint specified for " + action.name)
+		}
+		constraintSQL, err := action.constraint.constraintSQL(ab.dialect)
+		if err != nil {
+			return "", err
+		}
+		return "ADD CONSTRAINT " + action.name + " " + constraintSQL, nil
+
+	default:
+		return action.kind + " " + action.name, nil
+	}
+}
@@ -0,0 +1,174 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Query is a generated SQL statement with its arguments
+type Query struct {
+	SQL  string
+	Args []any
+}
+
+// maxParamsFor returns the bind parameter limit of the dialect
+func maxParamsFor(dialect Dialect) int {
+	switch dialect.(type) {
+	case sqlserverDialect:
+		return 2100
+	case sqliteDialect:
+		return 32766
+	default:
+		return 65535
+	}
+}
+
+// BuildBatches splits a multi-row VALUES insert into several statements
+// that each bind at most maxParams parameters. A maxParams of zero, or one
+// above the dialect's limit (65535 for Postgres, 2100 for SQL Server),
+// uses the dialect's limit. Inserts from a SELECT or with DEFAULT VALUES
+// are returned as a single statement.
+func (ib *insertBuilder) BuildBatches(maxParams int) ([]Query, error) {
+	if ib.err != nil {
+		return nil, ib.err
+	}
+	if limit := maxParamsFor(ib.dialect); maxParams <= 0 || maxParams > limit {
+		maxParams = limit
+	}
+
+	if len(ib.values) == 0 {
+		sql, args, err := ib.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		return []Query{{SQL: sql, Args: args}}, nil
+	}
+
+	budget := maxParams - ib.fixedParams()
+	var (
+		batches [][][]any
+		batch   [][]any
+		used    int
+	)
+	for i, row := range ib.values {
+		params := rowParams(row)
+		if params > budget {
+			return nil, fmt.Errorf("row %d needs %d parameters, more than the %d allowed per statement",
+				i+1, params, budget)
+		}
+		if used+params > budget {
+			batches = append(batches, batch)
+			batch, used = nil, 0
+		}
+		batch = append(batch, row)
+		used += params
+	}
+	batches = append(batches, batch)
+
+	queries := make([]Query, 0, len(batches))
+	for _, rows := range batches {
+		chunk := ib.Clone().(*insertBuilder)
+		chunk.values = rows
+		sql, args, err := chunk.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, Query{SQL: sql, Args: args})
+	}
+	return queries, nil
+}
+
+// fixedParams counts the parameters every batch binds besides its rows
+func (ib *insertBuilder) fixedParams() int {
+	var n int
+	for _, clause := range ib.prefixes {
+		n += len(clause.args)
+	}
+	for _, clause := range ib.suffixes {
+		n += len(clause.args)
+	}
+	for _, c := range ib.ctes {
+		if _, args, err := c.builder.ToSQL(); err == nil {
+			n += len(args)
+		}
+	}
+	if ib.conflict != nil {
+		n += conditionParams(ib.conflict.TargetWhere, ib.dialect) + conditionParams(ib.conflict.UpdateWhere, ib.dialect)
+		if !ib.conflict.DoNothing {
+			n += rowParams(slices.Collect(maps.Values(ib.conflict.DoUpdate)))
+		}
+	}
+	return n
+}
+
+// conditionParams counts the parameters bound by a condition
+func conditionParams(cond Condition, dialect Dialect) int {
+	if cond == nil {
+		return 0
+	}
+	var argPos int
+	_, args, _ := conditionToSQL(cond, dialect, &argPos)
+	return len(args)
+}
+
+// rowParams counts the values of a row bound as parameters
+func rowParams(row []any) int {
+	var n int
+	for _, val := range row {
+		switch v := val.(type) {
+		case rawSQL, excludedValue:
+		case Fragment:
+			n += len(v.Args())
+		case exprValue:
+			n += rowParams(v.args)
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// defaultDeleteChunk is the IN-list size used by BuildChunks by default;
+// it is also Oracle's limit on the number of IN-list expressions
+const defaultDeleteChunk = 1000
+
+// BuildChunks splits a delete of many keys into statements each removing
+// at most chunkSize of them with column IN (...), added to the builder's
+// WHERE conditions. A chunkSize of zero uses 1000, which is also the most
+// Oracle allows. Chunks are kept small enough for the keys and the
+// statement's other parameters to fit the dialect's limit (2100 on SQL
+// Server).
+func (db *deleteBuilder) BuildChunks(column string, keys []any, chunkSize int) ([]Query, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no keys specified")
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultDeleteChunk
+	}
+	if _, ok := db.dialect.(oracleDialect); ok && chunkSize > defaultDeleteChunk {
+		chunkSize = defaultDeleteChunk
+	}
+	_, fixedArgs, err := db.Clone().ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	maxKeys := maxParamsFor(db.dialect) - len(fixedArgs)
+	if maxKeys < 1 {
+		return nil, errors.New("the delete binds too many parameters to add keys")
+	}
+	chunkSize = min(chunkSize, maxKeys)
+
+	queries := make([]Query, 0, (len(keys)+chunkSize-1)/chunkSize)
+	for chunk := range slices.Chunk(keys, chunkSize) {
+		del := db.Clone().(*deleteBuilder)
+		del.where = append(del.where, In(column, chunk...))
+		sql, args, err := del.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, Query{SQL: sql, Args: args})
+	}
+	return queries, nil
+}
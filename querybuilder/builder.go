@@ -3,9 +3,29 @@ package querybuilder
 type Builder interface {
 	Select(columns ...string) SelectBuilder
 	Insert(table string) InsertBuilder
+	Upsert(table string) UpsertBuilder
 	Update(table string) UpdateBuilder
 	Delete(table string) DeleteBuilder
+	Truncate(table string) TruncateBuilder
 	WithDialect(dialect Dialect) Builder
+	CreateTable(table string) CreateTableBuilder
+	CreateTableAs(table string, query SelectBuilder) SQLBuilder
+	CreateIndex(name string) CreateIndexBuilder
+	AlterTable(table string) AlterTableBuilder
+	DropTable(table string) DropBuilder
+	DropIndex(name, table string) DropBuilder
+	DropView(view string) DropBuilder
+	Compound(query SelectBuilder) CompoundSelect
+	Tree(table, idColumn, parentColumn string) TreeQuery
+	WithTimestamps(createdAt, updatedAt string) Builder
+	WithSafeUpdates() Builder
+	WithUpdatedAt(column string, tables ...string) Builder
+	Count(column string) AggregateExpr
+	CountDistinct(column string) AggregateExpr
+	Sum(column string) AggregateExpr
+	Avg(column string) AggregateExpr
+	Min(column string) AggregateExpr
+	Max(column string) AggregateExpr
 }
 
 type SQLBuilder interface {
@@ -19,7 +39,10 @@ type Dialect interface {
 
 // QueryBuilder is the concrete implementation of Builder
 type QueryBuilder struct {
-	dialect Dialect
+	dialect     Dialect
+	timestamps  *timestampColumns
+	safeUpdates bool
+	updatedAt   map[string]string // per-table updatedAt column set by WithUpdatedAt
 }
 
 // New creates a new QueryBuilder instance
@@ -35,8 +58,12 @@ func (qb *QueryBuilder) WithDialect(dialect Dialect) Builder {
 
 // Select begins a SELECT query
 func (qb *QueryBuilder) Select(columns ...string) SelectBuilder {
+	selectColumns := make([]selectColumn, 0, len(columns))
+	for _, col := range columns {
+		selectColumns = append(selectColumns, selectColumn{expr: col})
+	}
 	return &selectBuilder{
-		columns:  columns,
+		columns:  selectColumns,
 		dialect:  qb.dialect,
 		distinct: false,
 	}
@@ -45,16 +72,27 @@ func (qb *QueryBuilder) Select(columns ...string) SelectBuilder {
 // Insert begins a INSERT query
 func (qb *QueryBuilder) Insert(table string) InsertBuilder {
 	return &insertBuilder{
-		table:   table,
-		dialect: qb.dialect,
+		table:      table,
+		dialect:    qb.dialect,
+		timestamps: qb.timestamps,
 	}
 }
 
+// WithSafeUpdates makes updates without a WHERE clause fail unless
+// AllRows is called, guarding against accidental full-table updates
+func (qb *QueryBuilder) WithSafeUpdates() Builder {
+	qb.safeUpdates = true
+	return qb
+}
+
 // Update begins an UPDATE query
 func (qb *QueryBuilder) Update(table string) UpdateBuilder {
 	return &updateBuilder{
-		table:   table,
-		dialect: qb.dialect,
+		table:      table,
+		dialect:    qb.dialect,
+		timestamps: qb.updateTimestamps(table),
+		stampsFor:  qb.updateTimestamps,
+		safe:       qb.safeUpdates,
 	}
 }
 
@@ -63,6 +101,7 @@ func (qb *QueryBuilder) Delete(table string) DeleteBuilder {
 	return &deleteBuilder{
 		table:   table,
 		dialect: qb.dialect,
+		update:  qb.Update,
 	}
 }
 
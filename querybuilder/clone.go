@@ -0,0 +1,108 @@
+package querybuilder
+
+import (
+	"maps"
+	"slices"
+)
+
+// cloneBuilder deep copies nested builders that support it so a cloned
+// statement does not share mutable state with the original
+func cloneBuilder(b SQLBuilder) SQLBuilder {
+	switch v := b.(type) {
+	case SelectBuilder:
+		return v.Clone()
+	case InsertBuilder:
+		return v.Clone()
+	case UpdateBuilder:
+		return v.Clone()
+	case DeleteBuilder:
+		return v.Clone()
+	case *subquery:
+		return v.clone()
+	default:
+		return b
+	}
+}
+
+func (s *subquery) clone() *subquery {
+	if s == nil {
+		return nil
+	}
+	return &subquery{builder: cloneBuilder(s.builder), alias: s.alias}
+}
+
+func cloneInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func cloneFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func cloneOrders(orders []order) []order {
+	cloned := slices.Clone(orders)
+	for i := range cloned {
+		cloned[i].args = slices.Clone(cloned[i].args)
+	}
+	return cloned
+}
+
+func cloneJoins(joins []join) []join {
+	cloned := slices.Clone(joins)
+	for i := range cloned {
+		cloned[i].subquery = cloned[i].subquery.clone()
+		cloned[i].conditions = slices.Clone(cloned[i].conditions)
+		cloned[i].using = slices.Clone(cloned[i].using)
+		cloned[i].hints = cloned[i].hints.clone()
+		cloned[i].values = cloned[i].values.clone()
+	}
+	return cloned
+}
+
+func (h tableHints) clone() tableHints {
+	return tableHints{index: slices.Clone(h.index), table: slices.Clone(h.table)}
+}
+
+func cloneCTEs(ctes []cte) []cte {
+	cloned := slices.Clone(ctes)
+	for i := range cloned {
+		cloned[i].builder = cloneBuilder(cloned[i].builder)
+	}
+	return cloned
+}
+
+func cloneConflict(c *ConflictAction) *ConflictAction {
+	if c == nil {
+		return nil
+	}
+	cloned := *c
+	cloned.DoUpdate = maps.Clone(c.DoUpdate)
+	return &cloned
+}
+
+func cloneRawClauses(clauses []rawClause) []rawClause {
+	cloned := slices.Clone(clauses)
+	for i := range cloned {
+		cloned[i].args = slices.Clone(cloned[i].args)
+	}
+	return cloned
+}
+
+func (v *valuesList) clone() *valuesList {
+	if v == nil {
+		return nil
+	}
+	rows := slices.Clone(v.rows)
+	for i := range rows {
+		rows[i] = slices.Clone(rows[i])
+	}
+	return &valuesList{rows: rows, alias: v.alias, columns: slices.Clone(v.columns)}
+}
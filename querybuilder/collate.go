@@ -0,0 +1,87 @@
+package querybuilder
+
+import (
+	"strings"
+)
+
+// Collate applies a collation to the column side of a condition, e.g.
+// Collate(Eq("name", "bob"), "NOCASE") renders name COLLATE NOCASE = ?.
+// AND / OR groups and NOT apply it to each of their conditions; conditions without
+// a column (EXISTS, row comparisons, custom conditions) are left unchanged.
+func Collate(condition Condition, collation string) NegatableCondition {
+	return &collatedCondition{condition: condition, collation: collation}
+}
+
+// collatedCondition handles COLLATE modifiers on conditions
+type collatedCondition struct {
+	condition Condition
+	collation string
+}
+
+func (c *collatedCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the collated condition, returning its errors
+func (c *collatedCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
+	return conditionToSQL(withCollation(c.condition, collateSQL(dialect, c.collation)), dialect, argPos)
+}
+
+// withCollation returns a copy of the condition whose column carries the
+// rendered COLLATE clause
+func withCollation(condition Condition, collate string) Condition {
+	switch c := condition.(type) {
+	case *baseCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *betweenCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *ilikeCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *inCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *quantifiedCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *literalLikeCondition:
+		collated := *c
+		collated.column += collate
+		return &collated
+	case *columnCondition:
+		collated := *c
+		collated.collate += collate
+		return &collated
+	case *logicalCondition:
+		collated := &logicalCondition{operator: c.operator}
+		for _, cond := range c.conditions {
+			collated.conditions = append(collated.conditions, withCollation(cond, collate))
+		}
+		return collated
+	case *notCondition:
+		return &notCondition{condition: withCollation(c.condition, collate)}
+	default:
+		return condition
+	}
+}
+
+// collateSQL renders " COLLATE name" for the dialect. PostgreSQL collation
+// names are identifiers such as "de_DE" and are quoted there; the other
+// dialects use bare names like NOCASE or utf8mb4_unicode_ci.
+func collateSQL(dialect Dialect, collation string) string {
+	if collation == "" {
+		return ""
+	}
+	if _, ok := dialect.(postgresDialect); ok && !strings.HasPrefix(collation, `"`) {
+		collation = `"` + strings.ReplaceAll(collation, `"`, `""`) + `"`
+	}
+	return " COLLATE " + collation
+}
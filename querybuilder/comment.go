@@ -0,0 +1,22 @@
+package querybuilder
+
+import (
+	"strings"
+)
+
+// sanitizeComment neutralizes comment delimiters in user supplied text so it
+// cannot terminate the surrounding comment (or open a nested one)
+func sanitizeComment(text string) string {
+	text = strings.ReplaceAll(text, "*/", "* /")
+	text = strings.ReplaceAll(text, "/*", "/ *")
+	return strings.TrimSpace(text)
+}
+
+// buildComments writes the statement prefix comments, e.g. for APM tagging
+func buildComments(query *strings.Builder, comments []string) {
+	for _, c := range comments {
+		query.WriteString("/* ")
+		query.WriteString(sanitizeComment(c))
+		query.WriteString(" */ ")
+	}
+}
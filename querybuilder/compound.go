@@ -0,0 +1,163 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// CompoundSelect interface for combining SELECT queries with set operations
+// and applying an ORDER BY / LIMIT / OFFSET to the combined result
+type CompoundSelect interface {
+	Union(query SelectBuilder) CompoundSelect
+	UnionAll(query SelectBuilder) CompoundSelect
+	Intersect(query SelectBuilder) CompoundSelect
+	Except(query SelectBuilder) CompoundSelect
+	OrderBy(column string, direction string) CompoundSelect
+	Limit(limit int) CompoundSelect
+	Offset(offset int) CompoundSelect
+	ToSQL() (string, []any, error)
+}
+
+// compoundBranch is a single SELECT of a compound query together with the
+// set operator joining it to the previous branch
+type compoundBranch struct {
+	operator string // "", "UNION", "UNION ALL", "INTERSECT", "EXCEPT"
+	query    SelectBuilder
+}
+
+// compoundSelect implements CompoundSelect
+type compoundSelect struct {
+	dialect  Dialect
+	branches []compoundBranch
+	orderBy  []order
+	limit    *int
+	offset   *int
+}
+
+// Compound begins a compound query with the given first SELECT
+func (qb *QueryBuilder) Compound(query SelectBuilder) CompoundSelect {
+	return &compoundSelect{
+		dialect:  qb.dialect,
+		branches: []compoundBranch{{query: query}},
+	}
+}
+
+// Union adds a UNION branch
+func (cs *compoundSelect) Union(query SelectBuilder) CompoundSelect {
+	return cs.add("UNION", query)
+}
+
+// UnionAll adds a UNION ALL branch
+func (cs *compoundSelect) UnionAll(query SelectBuilder) CompoundSelect {
+	return cs.add("UNION ALL", query)
+}
+
+// Intersect adds an INTERSECT branch
+func (cs *compoundSelect) Intersect(query SelectBuilder) CompoundSelect {
+	return cs.add("INTERSECT", query)
+}
+
+// Except adds an EXCEPT branch (MINUS on Oracle)
+func (cs *compoundSelect) Except(query SelectBuilder) CompoundSelect {
+	return cs.add("EXCEPT", query)
+}
+
+func (cs *compoundSelect) add(operator string, query SelectBuilder) CompoundSelect {
+	cs.branches = append(cs.branches, compoundBranch{operator: operator, query: query})
+	return cs
+}
+
+// OrderBy adds ORDER BY clause for the combined result
+func (cs *compoundSelect) OrderBy(column string, direction string) CompoundSelect {
+	if direction != "ASC" && direction != "DESC" {
+		direction = "ASC"
+	}
+	cs.orderBy = append(cs.orderBy, order{
+		column:    column,
+		direction: direction,
+	})
+	return cs
+}
+
+// Limit sets the LIMIT for the combined result
+func (cs *compoundSelect) Limit(limit int) CompoundSelect {
+	cs.limit = &limit
+	return cs
+}
+
+// Offset sets the OFFSET for the combined result
+func (cs *compoundSelect) Offset(offset int) CompoundSelect {
+	cs.offset = &offset
+	return cs
+}
+
+// ToSQL generates the SQL query and returns the query and parameters.
+// Branches are parenthesized so their own ORDER BY / LIMIT stay scoped to
+// them. SQLite does not accept parenthesized branches and SQL Server rejects
+// ORDER BY inside them, so there they are wrapped in SELECT * FROM (...)
+// instead.
+func (cs *compoundSelect) ToSQL() (string, []any, error) {
+	if len(cs.branches) < 2 {
+		return "", nil, errors.New("compound select requires at least two queries")
+	}
+
+	var (
+		query      strings.Builder
+		args       []any
+		paramCount int
+	)
+
+	for i, branch := range cs.branches {
+		if i > 0 {
+			operator := branch.operator
+			if _, ok := cs.dialect.(oracleDialect); ok && operator == "EXCEPT" {
+				operator = "MINUS"
+			}
+			query.WriteString(" ")
+			query.WriteString(operator)
+			query.WriteString(" ")
+		}
+
+		branchSQL, branchArgs, err := branch.query.ToSQL()
+		if err != nil {
+			return "", nil, err
+		}
+		branchSQL = shiftPlaceholders(branchSQL, cs.dialect, paramCount)
+
+		switch cs.dialect.(type) {
+		case sqliteDialect:
+			query.WriteString("SELECT * FROM (")
+			query.WriteString(branchSQL)
+			query.WriteString(")")
+		case sqlserverDialect:
+			query.WriteString("SELECT * FROM (")
+			query.WriteString(branchSQL)
+			query.WriteString(")")
+			query.WriteString(derivedTableAlias(cs.dialect, "b"))
+		default:
+			query.WriteString("(")
+			query.WriteString(branchSQL)
+			query.WriteString(")")
+		}
+		args = append(args, branchArgs...)
+		paramCount += len(branchArgs)
+	}
+
+	// The outer ORDER BY and pagination follow the same dialect rules as a
+	// plain select, except that SQL Server cannot use TOP here.
+	outer := &selectBuilder{
+		dialect:    cs.dialect,
+		orderBy:    cs.orderBy,
+		limit:      cs.limit,
+		offset:     cs.offset,
+		paramCount: paramCount,
+	}
+	if _, ok := cs.dialect.(sqlserverDialect); ok && outer.limit != nil && outer.offset == nil {
+		zero := 0
+		outer.offset = &zero
+	}
+	args = append(args, outer.buildOrderByClause(&query)...)
+	args = append(args, outer.buildPaginationClause(&query)...)
+
+	return query.String(), args, nil
+}
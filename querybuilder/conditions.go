@@ -1,7 +1,10 @@
 package querybuilder
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -10,6 +13,54 @@ type Condition interface {
 	ToSQL(dialect Dialect, argPos *int) (string, []any)
 }
 
+// ErrorCondition is implemented by conditions whose SQL generation can
+// fail, for example because an embedded subquery cannot be built. Builders
+// prefer ToSQLErr over ToSQL so such errors are returned from their own
+// ToSQL instead of producing broken SQL.
+type ErrorCondition interface {
+	Condition
+	ToSQLErr(dialect Dialect, argPos *int) (string, []any, error)
+}
+
+// conditionValidator is implemented by conditions that are not supported
+// by every dialect
+type conditionValidator interface {
+	validate(dialect Dialect) error
+}
+
+// conditionToSQL renders a condition, surfacing dialect validation errors
+// and the errors of conditions implementing ErrorCondition
+func conditionToSQL(cond Condition, dialect Dialect, argPos *int) (string, []any, error) {
+	if cond == nil {
+		return "", nil, errors.New("nil condition")
+	}
+	if v, ok := cond.(conditionValidator); ok {
+		if err := v.validate(dialect); err != nil {
+			return "", nil, err
+		}
+	}
+	if e, ok := cond.(ErrorCondition); ok {
+		return e.ToSQLErr(dialect, argPos)
+	}
+	sql, args := cond.ToSQL(dialect, argPos)
+	return sql, args, nil
+}
+
+// buildSubquery renders a subquery embedded in a condition, renumbering its
+// placeholders to follow argPos
+func buildSubquery(subq SQLBuilder, dialect Dialect, argPos *int) (string, []any, error) {
+	if subq == nil {
+		return "", nil, errors.New("nil subquery in condition")
+	}
+	sql, args, err := subq.ToSQL()
+	if err != nil {
+		return "", nil, fmt.Errorf("subquery in condition: %w", err)
+	}
+	sql = shiftPlaceholders(sql, dialect, *argPos)
+	*argPos += len(args)
+	return sql, args, nil
+}
+
 // Operator represents comparison operators
 type Operator string
 
@@ -22,6 +73,8 @@ const (
 	LessThanOrEqual  Operator = "<="
 	LikeOp           Operator = "LIKE"
 	NotLikeOp        Operator = "NOT LIKE"
+	ILikeOp          Operator = "ILIKE"
+	NotILikeOp       Operator = "NOT ILIKE"
 	InOp             Operator = "IN"
 	NotInOp          Operator = "NOT IN"
 	IsNullOp         Operator = "IS NULL"
@@ -39,19 +92,36 @@ type baseCondition struct {
 
 // ToSQL converts the condition to SQL with proper escaping
 func (c *baseCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr converts the condition to SQL, returning subquery build errors
+func (c *baseCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
 	var (
 		sql  strings.Builder
 		args []any
 	)
 
+	// Comparing with NULL never matches, so nil values become NULL checks
+	operator := c.operator
+	if c.valueType == "value" && isNilValue(c.value) {
+		switch operator {
+		case Equal:
+			operator = IsNullOp
+		case NotEqual:
+			operator = IsNotNullOp
+		}
+	}
+
 	// Column identifier
 	sql.WriteString(c.column)
 	sql.WriteString(" ")
-	sql.WriteString(string(c.operator))
+	sql.WriteString(string(operator))
 
 	// Handle NULL checks specially
-	if c.operator == IsNullOp || c.operator == IsNotNullOp {
-		return sql.String(), nil
+	if operator == IsNullOp || operator == IsNotNullOp {
+		return sql.String(), nil, nil
 	}
 
 	sql.WriteString(" ")
@@ -60,7 +130,11 @@ func (c *baseCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
 	case "column":
 		sql.WriteString(c.value.(string))
 	case "subquery":
-		subquery, subArgs, _ := c.value.(SQLBuilder).ToSQL()
+		subq, _ := c.value.(SQLBuilder)
+		subquery, subArgs, err := buildSubquery(subq, dialect, argPos)
+		if err != nil {
+			return "", nil, err
+		}
 		sql.WriteString("(")
 		sql.WriteString(subquery)
 		sql.WriteString(")")
@@ -72,11 +146,20 @@ func (c *baseCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
 		*argPos++
 	}
 
-	return sql.String(), args
+	return sql.String(), args, nil
+}
+
+// isNilValue reports whether the value is nil or a nil pointer
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
 }
 
 // NewCondition creates a new base condition
-func newCondition(column string, operator Operator, value any, valueType string) Condition {
+func newCondition(column string, operator Operator, value any, valueType string) NegatableCondition {
 	return &baseCondition{
 		column:    column,
 		operator:  operator,
@@ -85,68 +168,324 @@ func newCondition(column string, operator Operator, value any, valueType string)
 	}
 }
 
-// Eq creates an equality condition
-func Eq(column string, value any) Condition {
+// Eq creates an equality condition; a nil value generates IS NULL
+func Eq(column string, value any) NegatableCondition {
 	return newCondition(column, Equal, value, "value")
 }
 
-// NotEq creates an inequality condition
-func NotEq(column string, value any) Condition {
+// NotEq creates an inequality condition; a nil value generates IS NOT NULL
+func NotEq(column string, value any) NegatableCondition {
 	return newCondition(column, NotEqual, value, "value")
 }
 
 // Gt creates a greater-than condition
-func Gt(column string, value any) Condition {
+func Gt(column string, value any) NegatableCondition {
 	return newCondition(column, GreatThan, value, "value")
 }
 
 // GtOrEq creates a greater-than-or-equal condition
-func GtOrEq(column string, value any) Condition {
+func GtOrEq(column string, value any) NegatableCondition {
 	return newCondition(column, GreatThanOrEqual, value, "value")
 }
 
 // Lt creates a less-than condition
-func Lt(column string, value any) Condition {
+func Lt(column string, value any) NegatableCondition {
 	return newCondition(column, LessTnan, value, "value")
 }
 
 // LtOrEq creates a less-than-or-equal condition
-func LtOrEq(column string, value any) Condition {
+func LtOrEq(column string, value any) NegatableCondition {
 	return newCondition(column, LessThanOrEqual, value, "value")
 }
 
 // Like creates a LIKE condition
-func Like(column string, pattern any) Condition {
+func Like(column string, pattern any) NegatableCondition {
 	return newCondition(column, LikeOp, pattern, "value")
 }
 
 // NotLike creates a NOT LIKE condition
-func NotLike(column string, pattern any) Condition {
+func NotLike(column string, pattern any) NegatableCondition {
 	return newCondition(column, NotLikeOp, pattern, "value")
 }
 
+// likeEscape is the escape character of the LIKE patterns built by
+// StartsWith, EndsWith and Contains. It avoids backslash, whose meaning in
+// string literals differs between dialects.
+const likeEscape = "!"
+
+// StartsWith creates a column LIKE 'value%' condition, escaping the LIKE
+// wildcards in value so it is matched literally
+func StartsWith(column string, value string) NegatableCondition {
+	return &literalLikeCondition{column: column, value: value, suffix: "%"}
+}
+
+// EndsWith creates a column LIKE '%value' condition, escaping the LIKE
+// wildcards in value so it is matched literally
+func EndsWith(column string, value string) NegatableCondition {
+	return &literalLikeCondition{column: column, value: value, prefix: "%"}
+}
+
+// Contains creates a column LIKE '%value%' condition, escaping the LIKE
+// wildcards in value so it is matched literally
+func Contains(column string, value string) NegatableCondition {
+	return &literalLikeCondition{column: column, value: value, prefix: "%", suffix: "%"}
+}
+
+// literalLikeCondition handles LIKE matching of a literal value
+type literalLikeCondition struct {
+	column string
+	value  string
+	prefix string
+	suffix string
+}
+
+func (c *literalLikeCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	pattern := c.prefix + escapeLikeValue(c.value, dialect) + c.suffix
+	sql := fmt.Sprintf("%s LIKE %s ESCAPE '%s'", c.column, dialect.Placeholder(*argPos), likeEscape)
+	*argPos++
+	return sql, []any{pattern}
+}
+
+// escapeLikeValue escapes the LIKE wildcards of value with likeEscape.
+// SQL Server also treats [ as the start of a character class.
+func escapeLikeValue(value string, dialect Dialect) string {
+	special := likeEscape + "%_"
+	if _, ok := dialect.(sqlserverDialect); ok {
+		special += "["
+	}
+	var escaped strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(special, r) {
+			escaped.WriteString(likeEscape)
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// ILike creates a case-insensitive LIKE condition
+func ILike(column string, pattern any) NegatableCondition {
+	return &ilikeCondition{column: column, pattern: pattern}
+}
+
+// NotILike creates a case-insensitive NOT LIKE condition
+func NotILike(column string, pattern any) NegatableCondition {
+	return &ilikeCondition{column: column, pattern: pattern, not: true}
+}
+
+// ilikeCondition handles case-insensitive pattern matching
+type ilikeCondition struct {
+	column  string
+	pattern any
+	not     bool
+}
+
+// ToSQL emits ILIKE on PostgreSQL and LOWER(column) LIKE LOWER(?) elsewhere
+func (c *ilikeCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	if _, ok := dialect.(postgresDialect); ok {
+		operator := ILikeOp
+		if c.not {
+			operator = NotILikeOp
+		}
+		return newCondition(c.column, operator, c.pattern, "value").ToSQL(dialect, argPos)
+	}
+
+	operator := LikeOp
+	if c.not {
+		operator = NotLikeOp
+	}
+	sql := fmt.Sprintf("LOWER(%s) %s LOWER(%s)", c.column, operator, dialect.Placeholder(*argPos))
+	*argPos++
+	return sql, []any{c.pattern}
+}
+
+// Regexp creates a regular-expression match condition
+func Regexp(column string, pattern any) NegatableCondition {
+	return &regexpCondition{column: column, pattern: pattern}
+}
+
+// NotRegexp creates a negated regular-expression match condition
+func NotRegexp(column string, pattern any) NegatableCondition {
+	return &regexpCondition{column: column, pattern: pattern, not: true}
+}
+
+// regexpCondition handles regular-expression matching
+type regexpCondition struct {
+	column  string
+	pattern any
+	not     bool
+}
+
+func (c *regexpCondition) validate(dialect Dialect) error {
+	if _, ok := dialect.(sqlserverDialect); ok {
+		return errors.New("regular expression conditions are not supported by SQL Server")
+	}
+	return nil
+}
+
+// ToSQL emits REGEXP on MySQL and SQLite, ~ / !~ on PostgreSQL and
+// REGEXP_LIKE on Oracle
+func (c *regexpCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	var sql string
+	placeholder := dialect.Placeholder(*argPos)
+	switch dialect.(type) {
+	case postgresDialect:
+		operator := "~"
+		if c.not {
+			operator = "!~"
+		}
+		sql = fmt.Sprintf("%s %s %s", c.column, operator, placeholder)
+	case oracleDialect:
+		sql = fmt.Sprintf("REGEXP_LIKE(%s, %s)", c.column, placeholder)
+		if c.not {
+			sql = "NOT " + sql
+		}
+	default:
+		operator := "REGEXP"
+		if c.not {
+			operator = "NOT REGEXP"
+		}
+		sql = fmt.Sprintf("%s %s %s", c.column, operator, placeholder)
+	}
+	*argPos++
+	return sql, []any{c.pattern}
+}
+
+// EqAny creates a column = ANY(array) condition (PostgreSQL). The array is
+// passed through as a single arg for the driver to bind, e.g. pq.Array(ids).
+// When given a subquery it creates column = ANY (subquery) instead.
+func EqAny(column string, array any) NegatableCondition {
+	if subq, ok := array.(SQLBuilder); ok {
+		return Quantified(column, Equal, QuantifierAny, subq)
+	}
+	return &arrayCondition{column: column, operator: "= ANY", array: array}
+}
+
+// ArrayContains creates a column @> array condition (PostgreSQL)
+func ArrayContains(column string, array any) NegatableCondition {
+	return &arrayCondition{column: column, operator: "@>", array: array}
+}
+
+// ArrayOverlaps creates a column && array condition (PostgreSQL)
+func ArrayOverlaps(column string, array any) NegatableCondition {
+	return &arrayCondition{column: column, operator: "&&", array: array}
+}
+
+// arrayCondition handles PostgreSQL array operators
+type arrayCondition struct {
+	column   string
+	operator string
+	array    any
+}
+
+func (c *arrayCondition) validate(dialect Dialect) error {
+	if _, ok := dialect.(postgresDialect); !ok {
+		return fmt.Errorf("array operator %s is only supported by PostgreSQL", c.operator)
+	}
+	return nil
+}
+
+func (c *arrayCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	placeholder := dialect.Placeholder(*argPos)
+	*argPos++
+	if c.operator == "= ANY" {
+		return fmt.Sprintf("%s = ANY(%s)", c.column, placeholder), []any{c.array}
+	}
+	return fmt.Sprintf("%s %s %s", c.column, c.operator, placeholder), []any{c.array}
+}
+
+// EmptyInPredicate and EmptyNotInPredicate are emitted in place of IN and
+// NOT IN conditions without values, which are invalid SQL. By default an
+// empty IN matches no rows and an empty NOT IN matches every row.
+var (
+	EmptyInPredicate    = "1=0"
+	EmptyNotInPredicate = "1=1"
+)
+
 // In creates an IN condition
-func In(column string, values ...any) Condition {
-	return newCondition(column, InOp, values, "value")
+func In(column string, values ...any) NegatableCondition {
+	return &inCondition{column: column, values: values}
 }
 
 // NotIn creates a NOT IN condition
-func NotIn(column string, values ...any) Condition {
-	return newCondition(column, NotInOp, values, "value")
+func NotIn(column string, values ...any) NegatableCondition {
+	return &inCondition{column: column, values: values, not: true}
+}
+
+// InSubquery creates a column IN (subquery) condition
+func InSubquery(column string, subq SQLBuilder) NegatableCondition {
+	return newCondition(column, InOp, subq, "subquery")
+}
+
+// NotInSubquery creates a column NOT IN (subquery) condition
+func NotInSubquery(column string, subq SQLBuilder) NegatableCondition {
+	return newCondition(column, NotInOp, subq, "subquery")
+}
+
+// inCondition handles IN / NOT IN lists
+type inCondition struct {
+	column string
+	values []any
+	not    bool
+}
+
+// ToSQL emits one placeholder per value, e.g. id IN (?, ?, ?). Slice values
+// are expanded so In("id", ids) and In("id", ids...) are equivalent.
+func (c *inCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	values := flattenValues(c.values)
+	if len(values) == 0 {
+		if c.not {
+			return EmptyNotInPredicate, nil
+		}
+		return EmptyInPredicate, nil
+	}
+
+	operator := InOp
+	if c.not {
+		operator = NotInOp
+	}
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = dialect.Placeholder(*argPos)
+		*argPos++
+	}
+	return fmt.Sprintf("%s %s (%s)", c.column, operator, strings.Join(placeholders, ", ")), values
+}
+
+// flattenValues expands slice and array values into their elements. Byte
+// slices and driver.Valuer implementations (such as pq.StringArray) are kept
+// as single values since the driver binds them as a whole.
+func flattenValues(values []any) []any {
+	flat := make([]any, 0, len(values))
+	for _, value := range values {
+		if _, ok := value.(driver.Valuer); ok {
+			flat = append(flat, value)
+			continue
+		}
+		v := reflect.ValueOf(value)
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+			flat = append(flat, value)
+			continue
+		}
+		for i := 0; i < v.Len(); i++ {
+			flat = append(flat, v.Index(i).Interface())
+		}
+	}
+	return flat
 }
 
 // IsNull creates an IS NULL condition
-func IsNull(column string) Condition {
+func IsNull(column string) NegatableCondition {
 	return newCondition(column, IsNullOp, nil, "value")
 }
 
 // IsNotNull creates an IS NOT NULL condition
-func IsNotNull(column string) Condition {
+func IsNotNull(column string) NegatableCondition {
 	return newCondition(column, IsNotNullOp, nil, "value")
 }
 
 // Between creates a BETWEEN condition
-func Between(column string, from, to any) Condition {
+func Between(column string, from, to any) NegatableCondition {
 	return &betweenCondition{
 		column: column,
 		from:   from,
@@ -155,8 +494,48 @@ func Between(column string, from, to any) Condition {
 }
 
 // ColumnEq creates a column equality condition
-func ColumnEq(column1, column2 string) Condition {
-	return newCondition(column1, Equal, column2, "column")
+func ColumnEq(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: Equal, right: column2}
+}
+
+// ColumnNeq creates a column inequality condition
+func ColumnNeq(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: NotEqual, right: column2}
+}
+
+// ColumnGt creates a column1 > column2 condition
+func ColumnGt(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: GreatThan, right: column2}
+}
+
+// ColumnGte creates a column1 >= column2 condition
+func ColumnGte(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: GreatThanOrEqual, right: column2}
+}
+
+// ColumnLt creates a column1 < column2 condition
+func ColumnLt(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: LessTnan, right: column2}
+}
+
+// ColumnLte creates a column1 <= column2 condition
+func ColumnLte(column1, column2 string) NegatableCondition {
+	return &columnCondition{left: column1, operator: LessThanOrEqual, right: column2}
+}
+
+// columnCondition handles comparisons between two columns. Plain column
+// references are escaped with the dialect's identifier quoting; expressions
+// are written as given.
+type columnCondition struct {
+	left     string
+	operator Operator
+	right    string
+	collate  string // rendered COLLATE clause applied to the left column
+}
+
+func (c *columnCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	return fmt.Sprintf("%s%s %s %s",
+		escapeColumnRef(dialect, c.left), c.collate, c.operator, escapeColumnRef(dialect, c.right)), nil
 }
 
 // betweenCondition handles BETWEEN expressions
@@ -186,8 +565,261 @@ func (c *betweenCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
 	return sql.String(), args
 }
 
+// NullSafeEq creates an equality condition that treats two NULLs as equal
+// and a NULL and a value as different
+func NullSafeEq(column string, value any) NegatableCondition {
+	return &nullSafeEqCondition{column: column, value: value}
+}
+
+// nullSafeEqCondition handles NULL-safe equality: <=> on MySQL, IS on
+// SQLite, DECODE on Oracle, an explicit NULL check on SQL Server and
+// IS NOT DISTINCT FROM elsewhere
+type nullSafeEqCondition struct {
+	column string
+	value  any
+}
+
+func (c *nullSafeEqCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	placeholder := dialect.Placeholder(*argPos)
+	*argPos++
+
+	switch dialect.(type) {
+	case mysqlDialect:
+		return fmt.Sprintf("%s <=> %s", c.column, placeholder), []any{c.value}
+	case sqliteDialect:
+		return fmt.Sprintf("%s IS %s", c.column, placeholder), []any{c.value}
+	case oracleDialect:
+		return fmt.Sprintf("DECODE(%s, %s, 1, 0) = 1", c.column, placeholder), []any{c.value}
+	case sqlserverDialect:
+		second := dialect.Placeholder(*argPos)
+		*argPos++
+		return fmt.Sprintf("(%s = %s OR (%s IS NULL AND %s IS NULL))", c.column, placeholder, c.column, second),
+			[]any{c.value, c.value}
+	default:
+		return fmt.Sprintf("%s IS NOT DISTINCT FROM %s", c.column, placeholder), []any{c.value}
+	}
+}
+
+// BitAnd creates a (column & mask) = expected condition
+func BitAnd(column string, mask, expected any) NegatableCondition {
+	return &bitAndCondition{column: column, mask: mask, expected: expected}
+}
+
+// HasFlag creates a condition matching rows whose flag column has all the
+// bits of flag set
+func HasFlag(column string, flag any) NegatableCondition {
+	return BitAnd(column, flag, flag)
+}
+
+// bitAndCondition handles bitwise AND masks, using BITAND on Oracle which
+// has no & operator
+type bitAndCondition struct {
+	column   string
+	mask     any
+	expected any
+}
+
+func (c *bitAndCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	mask := dialect.Placeholder(*argPos)
+	*argPos++
+	expected := dialect.Placeholder(*argPos)
+	*argPos++
+
+	if _, ok := dialect.(oracleDialect); ok {
+		return fmt.Sprintf("BITAND(%s, %s) = %s", c.column, mask, expected), []any{c.mask, c.expected}
+	}
+	return fmt.Sprintf("(%s & %s) = %s", c.column, mask, expected), []any{c.mask, c.expected}
+}
+
+// Quantifier is the ANY / ALL / SOME keyword of a quantified comparison
+type Quantifier string
+
+const (
+	QuantifierAny  Quantifier = "ANY"
+	QuantifierAll  Quantifier = "ALL"
+	QuantifierSome Quantifier = "SOME"
+)
+
+// Quantified creates a column <operator> ANY|ALL|SOME (subquery) condition
+func Quantified(column string, operator Operator, quantifier Quantifier, subq SQLBuilder) NegatableCondition {
+	return &quantifiedCondition{column: column, operator: operator, quantifier: quantifier, subquery: subq}
+}
+
+// GtAll creates a column > ALL (subquery) condition
+func GtAll(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, GreatThan, QuantifierAll, subq)
+}
+
+// GtAny creates a column > ANY (subquery) condition
+func GtAny(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, GreatThan, QuantifierAny, subq)
+}
+
+// LtAll creates a column < ALL (subquery) condition
+func LtAll(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, LessTnan, QuantifierAll, subq)
+}
+
+// LtAny creates a column < ANY (subquery) condition
+func LtAny(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, LessTnan, QuantifierAny, subq)
+}
+
+// LtSome creates a column < SOME (subquery) condition
+func LtSome(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, LessTnan, QuantifierSome, subq)
+}
+
+// NotEqAll creates a column <> ALL (subquery) condition
+func NotEqAll(column string, subq SQLBuilder) NegatableCondition {
+	return Quantified(column, NotEqual, QuantifierAll, subq)
+}
+
+// quantifiedCondition handles ANY / ALL / SOME subquery comparisons
+type quantifiedCondition struct {
+	column     string
+	operator   Operator
+	quantifier Quantifier
+	subquery   SQLBuilder
+}
+
+func (c *quantifiedCondition) validate(dialect Dialect) error {
+	if _, ok := dialect.(sqliteDialect); ok {
+		return fmt.Errorf("%s subquery comparisons are not supported by SQLite", c.quantifier)
+	}
+	return nil
+}
+
+func (c *quantifiedCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the condition, returning subquery build errors
+func (c *quantifiedCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
+	subquery, subArgs, err := buildSubquery(c.subquery, dialect, argPos)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%s %s %s (%s)", c.column, c.operator, c.quantifier, subquery), subArgs, nil
+}
+
+// Exists creates an EXISTS (subquery) condition
+func Exists(subq SQLBuilder) NegatableCondition {
+	return &existsCondition{subquery: subq}
+}
+
+// NotExists creates a NOT EXISTS (subquery) condition
+func NotExists(subq SQLBuilder) NegatableCondition {
+	return &existsCondition{subquery: subq, not: true}
+}
+
+// existsCondition handles EXISTS / NOT EXISTS expressions
+type existsCondition struct {
+	subquery SQLBuilder
+	not      bool
+}
+
+func (c *existsCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the condition, returning subquery build errors
+func (c *existsCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
+	subquery, subArgs, err := buildSubquery(c.subquery, dialect, argPos)
+	if err != nil {
+		return "", nil, err
+	}
+	if c.not {
+		return "NOT EXISTS (" + subquery + ")", subArgs, nil
+	}
+	return "EXISTS (" + subquery + ")", subArgs, nil
+}
+
+// seekCondition handles keyset pagination predicates such as (a, b) > (?, ?)
+type seekCondition struct {
+	columns  []string
+	values   []any
+	operator Operator
+}
+
+// ToSQL emits a row value comparison where supported and expands it to
+// (a > ? OR (a = ? AND b > ?)) on SQL Server and Oracle
+func (c *seekCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	var (
+		sql  strings.Builder
+		args []any
+	)
+
+	switch dialect.(type) {
+	case sqlserverDialect, oracleDialect:
+		sql.WriteString("(")
+		for i := range c.columns {
+			if i > 0 {
+				sql.WriteString(" OR ")
+			}
+			sql.WriteString("(")
+			for j := 0; j < i; j++ {
+				sql.WriteString(c.columns[j])
+				sql.WriteString(" = ")
+				sql.WriteString(dialect.Placeholder(*argPos))
+				args = append(args, c.values[j])
+				*argPos++
+				sql.WriteString(" AND ")
+			}
+			sql.WriteString(c.columns[i])
+			sql.WriteString(" ")
+			sql.WriteString(string(c.operator))
+			sql.WriteString(" ")
+			sql.WriteString(dialect.Placeholder(*argPos))
+			args = append(args, c.values[i])
+			*argPos++
+			sql.WriteString(")")
+		}
+		sql.WriteString(")")
+	default:
+		placeholders := make([]string, len(c.values))
+		for i, v := range c.values {
+			placeholders[i] = dialect.Placeholder(*argPos)
+			args = append(args, v)
+			*argPos++
+		}
+		sql.WriteString("(")
+		sql.WriteString(strings.Join(c.columns, ", "))
+		sql.WriteString(") ")
+		sql.WriteString(string(c.operator))
+		sql.WriteString(" (")
+		sql.WriteString(strings.Join(placeholders, ", "))
+		sql.WriteString(")")
+	}
+
+	return sql.String(), args
+}
+
+// Expr creates a raw condition such as Expr("price * quantity > ?", 100).
+// Its "?" placeholders are rewritten to the dialect's style and the
+// expression is parenthesized so it combines safely with other conditions.
+func Expr(sql string, args ...any) NegatableCondition {
+	return &exprCondition{sql: sql, args: args}
+}
+
+// exprCondition handles raw condition fragments with bound args
+type exprCondition struct {
+	sql  string
+	args []any
+}
+
+func (c *exprCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql := c.sql
+	if len(c.args) > 0 {
+		sql = bindPlaceholders(sql, dialect, argPos)
+	}
+	return "(" + sql + ")", c.args
+}
+
 // And combines conditions with AND
-func And(conditions ...Condition) Condition {
+func And(conditions ...Condition) NegatableCondition {
 	return &logicalCondition{
 		operator:   "AND",
 		conditions: conditions,
@@ -195,7 +827,7 @@ func And(conditions ...Condition) Condition {
 }
 
 // Or combines conditions with OR
-func Or(conditions ...Condition) Condition {
+func Or(conditions ...Condition) NegatableCondition {
 	return &logicalCondition{
 		operator:   "OR",
 		conditions: conditions,
@@ -209,8 +841,14 @@ type logicalCondition struct {
 }
 
 func (c *logicalCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the group, returning the first error of its conditions
+func (c *logicalCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
 	if len(c.conditions) == 0 {
-		return "", nil
+		return "", nil, nil
 	}
 
 	var (
@@ -220,7 +858,10 @@ func (c *logicalCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
 	)
 
 	for _, cond := range c.conditions {
-		partSQL, partArgs := cond.ToSQL(dialect, argPos)
+		partSQL, partArgs, err := conditionToSQL(cond, dialect, argPos)
+		if err != nil {
+			return "", nil, err
+		}
 		parts = append(parts, partSQL)
 		allArgs = append(allArgs, partArgs...)
 	}
@@ -235,21 +876,27 @@ func (c *logicalCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
 		sql.WriteString(")")
 	}
 
-	return sql.String(), allArgs
+	return sql.String(), allArgs, nil
 }
 
 // Helper function to build conditions (shared with select/delete builders)
-func buildConditions(conditions []Condition, dialect Dialect, paramCount *int) (string, []interface{}) {
+func buildConditions(conditions []Condition, dialect Dialect, paramCount *int) (string, []interface{}, error) {
 	var (
 		sqlParts []string
 		args     []interface{}
 	)
 
 	for _, cond := range conditions {
-		sql, condArgs := cond.ToSQL(dialect, paramCount)
+		sql, condArgs, err := conditionToSQL(cond, dialect, paramCount)
+		if err != nil {
+			return "", nil, err
+		}
+		if sql == "" {
+			continue
+		}
 		sqlParts = append(sqlParts, sql)
 		args = append(args, condArgs...)
 	}
 
-	return strings.Join(sqlParts, " AND "), args
+	return strings.Join(sqlParts, " AND "), args, nil
 }
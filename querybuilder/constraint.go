@@ -0,0 +1,149 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// Constraint is a table constraint built by ForeignKey, Unique or Check
+// and added with CreateTable or AlterTable
+type Constraint interface {
+	constraintSQL(dialect Dialect) (string, error)
+}
+
+// ReferentialAction is what a foreign key does when the referenced row is
+// deleted or updated
+type ReferentialAction string
+
+const (
+	Cascade    ReferentialAction = "CASCADE"
+	SetNull    ReferentialAction = "SET NULL"
+	SetDefault ReferentialAction = "SET DEFAULT"
+	Restrict   ReferentialAction = "RESTRICT"
+	NoAction   ReferentialAction = "NO ACTION"
+)
+
+// ForeignKeyConstraint is a FOREIGN KEY constraint
+type ForeignKeyConstraint struct {
+	columns    []string
+	refTable   string
+	refColumns []string
+	onDelete   ReferentialAction
+	onUpdate   ReferentialAction
+}
+
+// ForeignKey begins a FOREIGN KEY constraint on columns
+func ForeignKey(columns ...string) *ForeignKeyConstraint {
+	return &ForeignKeyConstraint{columns: columns}
+}
+
+// References sets the referenced table and columns
+func (fk *ForeignKeyConstraint) References(table string, columns ...string) *ForeignKeyConstraint {
+	fk.refTable = table
+	fk.refColumns = columns
+	return fk
+}
+
+// OnDelete sets the action taken when the referenced row is deleted
+func (fk *ForeignKeyConstraint) OnDelete(action ReferentialAction) *ForeignKeyConstraint {
+	fk.onDelete = action
+	return fk
+}
+
+// OnUpdate sets the action taken when the referenced key is updated. Not
+// supported by Oracle.
+func (fk *ForeignKeyConstraint) OnUpdate(action ReferentialAction) *ForeignKeyConstraint {
+	fk.onUpdate = action
+	return fk
+}
+
+func (fk *ForeignKeyConstraint) constraintSQL(dialect Dialect) (string, error) {
+	if len(fk.columns) == 0 {
+		return "", errors.New("no foreign key columns specified")
+	}
+	if fk.refTable == "" || len(fk.refColumns) == 0 {
+		return "", errors.New("no referenced table and columns specified")
+	}
+	if len(fk.columns) != len(fk.refColumns) {
+		return "", errors.New("foreign key and referenced columns differ in number")
+	}
+
+	var sql strings.Builder
+	sql.WriteString("FOREIGN KEY (" + strings.Join(fk.columns, ", ") + ")")
+	sql.WriteString(" REFERENCES " + escapeTableRef(dialect, fk.refTable) + " (" + strings.Join(fk.refColumns, ", ") + ")")
+	for _, rule := range []struct {
+		event  string
+		action ReferentialAction
+	}{{"DELETE", fk.onDelete}, {"UPDATE", fk.onUpdate}} {
+		if rule.action == "" {
+			continue
+		}
+		if err := checkReferentialAction(dialect, rule.event, rule.action); err != nil {
+			return "", err
+		}
+		sql.WriteString(" ON " + rule.event + " " + string(rule.action))
+	}
+	return sql.String(), nil
+}
+
+// checkReferentialAction returns an error when the dialect doesn't support
+// the action on the event
+func checkReferentialAction(dialect Dialect, event string, action ReferentialAction) error {
+	switch dialect.(type) {
+	case sqlserverDialect:
+		if action == Restrict {
+			return errors.New("ON " + event + " RESTRICT is not supported by SQL Server, use NO ACTION")
+		}
+	case oracleDialect:
+		if event == "UPDATE" || (action != Cascade && action != SetNull) {
+			return errors.New("Oracle only supports ON DELETE CASCADE and ON DELETE SET NULL")
+		}
+	}
+	return nil
+}
+
+// uniqueConstraint is a UNIQUE constraint
+type uniqueConstraint struct {
+	columns []string
+}
+
+// Unique creates a UNIQUE constraint on columns
+func Unique(columns ...string) Constraint {
+	return uniqueConstraint{columns: columns}
+}
+
+func (u uniqueConstraint) constraintSQL(Dialect) (string, error) {
+	if len(u.columns) == 0 {
+		return "", errors.New("no unique columns specified")
+	}
+	return "UNIQUE (" + strings.Join(u.columns, ", ") + ")", nil
+}
+
+// checkConstraint is a CHECK constraint
+type checkConstraint struct {
+	condition Condition
+}
+
+// Check creates a CHECK constraint from a condition. Since DDL cannot bind
+// parameters, the condition must not have args: use Expr with literals.
+func Check(condition Condition) Constraint {
+	return checkConstraint{condition: condition}
+}
+
+func (c checkConstraint) constraintSQL(dialect Dialect) (string, error) {
+	if c.condition == nil {
+		return "", errors.New("no check condition specified")
+	}
+	paramCount := 0
+	sql, args, err := conditionToSQL(c.condition, dialect, &paramCount)
+	if err != nil {
+		return "", err
+	}
+	if len(args) > 0 {
+		return "", errors.New("check constraint cannot bind parameters, use Expr with literal values")
+	}
+	if sql == "" {
+		return "", errors.New("no check condition specified")
+	}
+	return "CHECK (" + sql + ")", nil
+}
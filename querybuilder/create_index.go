@@ -0,0 +1,192 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// CreateIndexBuilder builds CREATE INDEX statements
+type CreateIndexBuilder interface {
+	On(table string, columns ...string) CreateIndexBuilder
+	Unique() CreateIndexBuilder
+	Where(conditions ...Condition) CreateIndexBuilder
+	Using(method string) CreateIndexBuilder
+	Concurrently() CreateIndexBuilder
+	ToSQL() (string, []any, error)
+}
+
+// createIndexBuilder implements CreateIndexBuilder
+type createIndexBuilder struct {
+	dialect      Dialect
+	name         string
+	table        string
+	columns      []string
+	unique       bool
+	where        []Condition
+	method       string
+	concurrently bool
+}
+
+// CreateIndex begins a CREATE INDEX statement
+func (qb *QueryBuilder) CreateIndex(name string) CreateIndexBuilder {
+	return &createIndexBuilder{
+		dialect: qb.dialect,
+		name:    name,
+	}
+}
+
+// On sets the indexed table and its key columns. A column may carry a sort
+// direction ("created_at DESC") or be an expression ("LOWER(email)").
+func (cb *createIndexBuilder) On(table string, columns ...string) CreateIndexBuilder {
+	cb.table = table
+	cb.columns = append(cb.columns, columns...)
+	return cb
+}
+
+// Unique makes the index reject duplicate keys
+func (cb *createIndexBuilder) Unique() CreateIndexBuilder {
+	cb.unique = true
+	return cb
+}
+
+// Where makes the index partial, covering only the rows matching the
+// conditions. Supported by PostgreSQL, SQLite and SQL Server; since DDL
+// cannot bind parameters, the conditions must not have args.
+func (cb *createIndexBuilder) Where(conditions ...Condition) CreateIndexBuilder {
+	cb.where = append(cb.where, conditions...)
+	return cb
+}
+
+// Using sets the index method, such as "gin" on PostgreSQL or "HASH" on
+// MySQL
+func (cb *createIndexBuilder) Using(method string) CreateIndexBuilder {
+	cb.method = method
+	return cb
+}
+
+// Concurrently builds the index without blocking writes: CONCURRENTLY on
+// PostgreSQL, ALGORITHM=INPLACE LOCK=NONE on MySQL, WITH (ONLINE = ON) on
+// SQL Server and ONLINE on Oracle
+func (cb *createIndexBuilder) Concurrently() CreateIndexBuilder {
+	cb.concurrently = true
+	return cb
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (cb *createIndexBuilder) ToSQL() (string, []any, error) {
+	if cb.name == "" {
+		return "", nil, errors.New("no index name specified")
+	}
+	if cb.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+	if len(cb.columns) == 0 {
+		return "", nil, errors.New("no index columns specified")
+	}
+	if err := cb.validate(); err != nil {
+		return "", nil, err
+	}
+
+	var query strings.Builder
+	query.WriteString("CREATE ")
+	if cb.unique {
+		query.WriteString("UNIQUE ")
+	}
+	query.WriteString("INDEX ")
+	if _, ok := cb.dialect.(postgresDialect); ok && cb.concurrently {
+		query.WriteString("CONCURRENTLY ")
+	}
+	query.WriteString(cb.name)
+	if _, ok := cb.dialect.(mysqlDialect); ok && cb.method != "" {
+		query.WriteString(" USING " + strings.ToUpper(cb.method))
+	}
+	query.WriteString(" ON " + escapeTableRef(cb.dialect, cb.table))
+	if _, ok := cb.dialect.(postgresDialect); ok && cb.method != "" {
+		query.WriteString(" USING " + cb.method)
+	}
+
+	query.WriteString(" (")
+	for i, col := range cb.columns {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(cb.keyPart(col))
+	}
+	query.WriteString(")")
+
+	if len(cb.where) > 0 {
+		paramCount := 0
+		whereSQL, whereArgs, err := buildConditions(cb.where, cb.dialect, &paramCount)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(whereArgs) > 0 {
+			return "", nil, errors.New("index predicate cannot bind parameters, use Expr with literal values")
+		}
+		if whereSQL != "" {
+			query.WriteString(" WHERE " + whereSQL)
+		}
+	}
+
+	if cb.concurrently {
+		switch cb.dialect.(type) {
+		case mysqlDialect:
+			query.WriteString(" ALGORITHM=INPLACE LOCK=NONE")
+		case sqlserverDialect:
+			query.WriteString(" WITH (ONLINE = ON)")
+		case oracleDialect:
+			query.WriteString(" ONLINE")
+		}
+	}
+	return query.String(), nil, nil
+}
+
+// validate checks that the dialect supports the requested index options
+func (cb *createIndexBuilder) validate() error {
+	switch cb.dialect.(type) {
+	case postgresDialect:
+	case mysqlDialect:
+		if len(cb.where) > 0 {
+			return errors.New("partial indexes are not supported by MySQL")
+		}
+	case sqlserverDialect:
+		if cb.method != "" {
+			return errors.New("index methods are not supported by SQL Server")
+		}
+		for _, col := range cb.columns {
+			if isIndexExpression(col) {
+				return errors.New("expression indexes are not supported by SQL Server, index a computed column instead")
+			}
+		}
+	case oracleDialect:
+		if cb.method != "" || len(cb.where) > 0 {
+			return errors.New("index methods and partial indexes are not supported by Oracle")
+		}
+	default:
+		if cb.method != "" || cb.concurrently {
+			return errors.New("index methods and concurrent builds are not supported by SQLite")
+		}
+	}
+	return nil
+}
+
+// keyPart renders an index column. Expressions are parenthesized, as
+// PostgreSQL, MySQL and SQLite require, leaving out a trailing sort
+// direction; Oracle takes them as is.
+func (cb *createIndexBuilder) keyPart(col string) string {
+	if _, ok := cb.dialect.(oracleDialect); ok || !isIndexExpression(col) {
+		return col
+	}
+	for _, direction := range []string{" ASC", " DESC"} {
+		if len(col) > len(direction) && strings.EqualFold(col[len(col)-len(direction):], direction) {
+			return "(" + col[:len(col)-len(direction)] + ")" + col[len(col)-len(direction):]
+		}
+	}
+	return "(" + col + ")"
+}
+
+// isIndexExpression reports whether an index column is an expression
+// rather than a column name
+func isIndexExpression(col string) bool {
+	return strings.ContainsAny(col, "()")
+}
@@ -0,0 +1,160 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// createTableAsBuilder builds CREATE TABLE ... AS SELECT statements
+type createTableAsBuilder struct {
+	dialect Dialect
+	table   string
+	query   SelectBuilder
+}
+
+// CreateTableAs begins a CREATE TABLE ... AS SELECT statement
+func (qb *QueryBuilder) CreateTableAs(table string, query SelectBuilder) SQLBuilder {
+	return &createTableAsBuilder{
+		dialect: qb.dialect,
+		table:   table,
+		query:   query,
+	}
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (cb *createTableAsBuilder) ToSQL() (string, []any, error) {
+	if cb.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+	if cb.query == nil {
+		return "", nil, errors.New("no select query specified")
+	}
+	if _, ok := cb.dialect.(sqlserverDialect); ok {
+		return "", nil, errors.New("CREATE TABLE ... AS SELECT is not supported by SQL Server, use SELECT ... INTO")
+	}
+
+	selectSQL, selectArgs, err := cb.query.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var query strings.Builder
+	query.WriteString("CREATE TABLE ")
+	query.WriteString(escapeTableRef(cb.dialect, cb.table))
+	query.WriteString(" AS ")
+	query.WriteString(selectSQL)
+
+	return query.String(), selectArgs, nil
+}
+
+// CreateTableBuilder builds CREATE TABLE statements
+type CreateTableBuilder interface {
+	Column(name, definition string) CreateTableBuilder
+	PrimaryKey(columns ...string) CreateTableBuilder
+	Constraint(name string, constraint Constraint) CreateTableBuilder
+	IfNotExists() CreateTableBuilder
+	ToSQL() (string, []any, error)
+}
+
+// createTableBuilder implements CreateTableBuilder
+type createTableBuilder struct {
+	dialect     Dialect
+	table       string
+	columns     []columnDef
+	primaryKey  []string
+	constraints []alterAction
+	ifNotExists bool
+}
+
+// columnDef is a column of a CREATE TABLE statement
+type columnDef struct {
+	name       string
+	definition string
+}
+
+// CreateTable begins a CREATE TABLE statement
+func (qb *QueryBuilder) CreateTable(table string) CreateTableBuilder {
+	return &createTableBuilder{
+		dialect: qb.dialect,
+		table:   table,
+	}
+}
+
+// Column adds a column; definition is its type followed by any DEFAULT and
+// NOT NULL, e.g. "VARCHAR(255) DEFAULT 'n/a' NOT NULL"
+func (cb *createTableBuilder) Column(name, definition string) CreateTableBuilder {
+	cb.columns = append(cb.columns, columnDef{name: name, definition: definition})
+	return cb
+}
+
+// PrimaryKey sets the primary key columns
+func (cb *createTableBuilder) PrimaryKey(columns ...string) CreateTableBuilder {
+	cb.primaryKey = columns
+	return cb
+}
+
+// Constraint adds a named table constraint. An empty name leaves the
+// constraint unnamed.
+func (cb *createTableBuilder) Constraint(name string, constraint Constraint) CreateTableBuilder {
+	cb.constraints = append(cb.constraints, alterAction{name: name, constraint: constraint})
+	return cb
+}
+
+// IfNotExists skips creating the table when it already exists. Not
+// supported by SQL Server and Oracle.
+func (cb *createTableBuilder) IfNotExists() CreateTableBuilder {
+	cb.ifNotExists = true
+	return cb
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (cb *createTableBuilder) ToSQL() (string, []any, error) {
+	if cb.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+	if len(cb.columns) == 0 {
+		return "", nil, errors.New("no columns specified")
+	}
+	if cb.ifNotExists {
+		switch cb.dialect.(type) {
+		case sqlserverDialect, oracleDialect:
+			return "", nil, errors.New("CREATE TABLE IF NOT EXISTS is not supported by this dialect")
+		}
+	}
+
+	var defs []string
+	for _, col := range cb.columns {
+		if col.name == "" || col.definition == "" {
+			return "", nil, errors.New("column name and definition are required")
+		}
+		defs = append(defs, col.name+" "+col.definition)
+	}
+	if len(cb.primaryKey) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(cb.primaryKey, ", ")+")")
+	}
+	for _, c := range cb.constraints {
+		if c.constraint == nil {
+			return "", nil, errors.New("no constraint specified for " + c.name)
+		}
+		constraintSQL, err := c.constraint.constraintSQL(cb.dialect)
+		if err != nil {
+			return "", nil, err
+		}
+		if c.name != "" {
+			constraintSQL = "CONSTRAINT " + c.name + " " + constraintSQL
+		}
+		defs = append(defs, constraintSQL)
+	}
+
+	var query strings.Builder
+	query.WriteString("CREATE TABLE ")
+	if cb.ifNotExists {
+		query.WriteString("IF NOT EXISTS ")
+	}
+	query.WriteString(escapeTableRef(cb.dialect, cb.table))
+	query.WriteString(" (")
+	query.WriteString(strings.Join(defs, ", "))
+	query.WriteString(")")
+
+	return query.String(), nil, nil
+}
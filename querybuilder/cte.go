@@ -0,0 +1,60 @@
+package querybuilder
+
+import (
+	"strings"
+)
+
+// cte represents a single common table expression in a WITH clause
+type cte struct {
+	alias     string
+	builder   SQLBuilder
+	recursive bool
+}
+
+// buildWithClause writes the WITH block for the given CTEs and returns their
+// args. Placeholders of each CTE are renumbered to continue from paramCount,
+// which is advanced past the CTE args so the main statement follows them.
+func buildWithClause(query *strings.Builder, ctes []cte, dialect Dialect, paramCount *int) ([]any, error) {
+	if len(ctes) == 0 {
+		return nil, nil
+	}
+
+	var args []any
+
+	query.WriteString("WITH ")
+	if hasRecursiveCTE(ctes) {
+		switch dialect.(type) {
+		case postgresDialect, mysqlDialect, sqliteDialect:
+			query.WriteString("RECURSIVE ")
+		}
+	}
+
+	for i, c := range ctes {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		cteSQL, cteArgs, err := c.builder.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(c.alias)
+		query.WriteString(" AS (")
+		query.WriteString(shiftPlaceholders(cteSQL, dialect, *paramCount))
+		query.WriteString(")")
+		args = append(args, cteArgs...)
+		*paramCount += len(cteArgs)
+	}
+	query.WriteString(" ")
+
+	return args, nil
+}
+
+// hasRecursiveCTE reports whether any of the CTEs was declared recursive
+func hasRecursiveCTE(ctes []cte) bool {
+	for _, c := range ctes {
+		if c.recursive {
+			return true
+		}
+	}
+	return false
+}
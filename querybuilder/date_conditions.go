@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"fmt"
+	"time"
+)
+
+// OnDate creates a condition matching the whole calendar day of date (in
+// date's location). It is emitted as a half-open range,
+// column >= ? AND column < ?, which works on every dialect and can use an
+// index on the column, unlike truncating the column to a date.
+func OnDate(column string, date time.Time) NegatableCondition {
+	return BetweenDates(column, date, date)
+}
+
+// BetweenDates creates a condition matching the calendar days from through
+// to, both included, as a half-open range like OnDate
+func BetweenDates(column string, from, to time.Time) NegatableCondition {
+	return &dateRangeCondition{
+		column: column,
+		from:   startOfDay(from),
+		to:     startOfDay(to).AddDate(0, 0, 1),
+	}
+}
+
+// WithinLast creates a condition matching values from d ago until now,
+// using the database clock and the dialect's interval arithmetic.
+// The duration is rounded down to whole seconds.
+func WithinLast(column string, d time.Duration) NegatableCondition {
+	return &withinLastCondition{column: column, seconds: int64(d.Abs() / time.Second)}
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// dateRangeCondition handles half-open date ranges
+type dateRangeCondition struct {
+	column string
+	from   time.Time
+	to     time.Time
+}
+
+func (c *dateRangeCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	from := dialect.Placeholder(*argPos)
+	*argPos++
+	to := dialect.Placeholder(*argPos)
+	*argPos++
+	return fmt.Sprintf("%s >= %s AND %s < %s", c.column, from, c.column, to), []any{c.from, c.to}
+}
+
+// withinLastCondition handles "in the last n seconds" filters
+type withinLastCondition struct {
+	column  string
+	seconds int64
+}
+
+func (c *withinLastCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	var since string
+	switch dialect.(type) {
+	case postgresDialect:
+		since = fmt.Sprintf("NOW() - INTERVAL '%d seconds'", c.seconds)
+	case mysqlDialect:
+		since = fmt.Sprintf("NOW() - INTERVAL %d SECOND", c.seconds)
+	case sqliteDialect:
+		since = fmt.Sprintf("datetime('now', '-%d seconds')", c.seconds)
+	case sqlserverDialect:
+		since = fmt.Sprintf("DATEADD(second, -%d, SYSDATETIME())", c.seconds)
+	case oracleDialect:
+		since = fmt.Sprintf("SYSTIMESTAMP - NUMTODSINTERVAL(%d, 'SECOND')", c.seconds)
+	default:
+		since = fmt.Sprintf("CURRENT_TIMESTAMP - INTERVAL '%d' SECOND", c.seconds)
+	}
+	return c.column + " >= " + since, nil
+}
@@ -3,47 +3,88 @@ package querybuilder
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 )
 
 // DeleteBuilder interface for constructing DELETE queries
 type DeleteBuilder interface {
 	From(table string) DeleteBuilder
+	As(alias string) DeleteBuilder
 	Where(conditions ...Condition) DeleteBuilder
+	WhereIf(ok bool, conditions ...Condition) DeleteBuilder
+	WhereGroup(fn func(g ConditionGroup)) DeleteBuilder
+	WalkWhere(fn func(node ConditionNode) bool)
+	RewriteWhere(fn func(node ConditionNode) Condition) DeleteBuilder
 	OrderBy(column string, direction string) DeleteBuilder
 	Limit(limit int) DeleteBuilder
 	Returning(columns ...string) DeleteBuilder
 	ToSQL() (string, []any, error)
+	Clone() DeleteBuilder
+	Comment(text string) DeleteBuilder
+	Prefix(sql string, args ...any) DeleteBuilder
+	Suffix(sql string, args ...any) DeleteBuilder
 	Join(table, on string) DeleteBuilder
 	LeftJoin(table, on string) DeleteBuilder
 	RightJoin(table, on string) DeleteBuilder
+	JoinOn(table string, conditions ...Condition) DeleteBuilder
+	LeftJoinOn(table string, conditions ...Condition) DeleteBuilder
+	RightJoinOn(table string, conditions ...Condition) DeleteBuilder
+	JoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder
+	LeftJoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder
+	RightJoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder
+	With(alias string, builder SQLBuilder) DeleteBuilder
+	WithRecursive(alias string, builder SQLBuilder) DeleteBuilder
+	Using(table string) DeleteBuilder
+	UsingSubquery(subq SQLBuilder, alias string) DeleteBuilder
+	DeleteFrom(targets ...string) DeleteBuilder
+	SoftDelete(column string) DeleteBuilder
+	BuildChunks(column string, keys []any, chunkSize int) ([]Query, error)
 }
 
 // deleteBuilder implements DeleteBuilder
 type deleteBuilder struct {
 	dialect    Dialect
 	table      string
+	alias      string
 	where      []Condition
 	orderBy    []order
 	limit      *int
 	returning  []string
 	paramCount int
 	joins      []join
+	using      []join   // tables and subqueries the delete reads from
+	targets    []string // tables or aliases a multi-table delete removes rows from
+	softDelete string   // column set by SoftDelete instead of deleting rows
+	ctes       []cte
+	comments   []string
+	prefixes   []rawClause
+	suffixes   []rawClause
+
+	// update is the Update of the builder, which SoftDelete renders through
+	update func(table string) UpdateBuilder
 }
 
 type order struct {
 	column    string
 	direction string
+	nulls     string // "", "FIRST", "LAST"
+	collation string
+	raw       bool
+	args      []any
 }
 
 // NewDeleteBuilder creates a new DeleteBuilder instance
 func (qb *QueryBuilder) NewDeleteBuilder() DeleteBuilder {
 	return &deleteBuilder{
 		dialect: qb.dialect,
+		update:  qb.Update,
 	}
 }
 
-// Join implementations
+// Join implementations. PostgreSQL has no DELETE ... JOIN, so there the
+// joined tables render as USING with their ON predicates in WHERE; SQLite
+// and Oracle deletes cannot join.
 func (db *deleteBuilder) Join(table, on string) DeleteBuilder {
 	db.joins = append(db.joins, join{
 		joinType:  "INNER",
@@ -71,18 +112,136 @@ func (db *deleteBuilder) RightJoin(table, on string) DeleteBuilder {
 	return db
 }
 
+// JoinOn adds an INNER JOIN whose ON clause is built from conditions
+func (db *deleteBuilder) JoinOn(table string, conditions ...Condition) DeleteBuilder {
+	return db.joinOn("INNER", table, conditions)
+}
+
+// LeftJoinOn adds a LEFT JOIN whose ON clause is built from conditions
+func (db *deleteBuilder) LeftJoinOn(table string, conditions ...Condition) DeleteBuilder {
+	return db.joinOn("LEFT", table, conditions)
+}
+
+// RightJoinOn adds a RIGHT JOIN whose ON clause is built from conditions
+func (db *deleteBuilder) RightJoinOn(table string, conditions ...Condition) DeleteBuilder {
+	return db.joinOn("RIGHT", table, conditions)
+}
+
+func (db *deleteBuilder) joinOn(joinType, table string, conditions []Condition) DeleteBuilder {
+	db.joins = append(db.joins, join{
+		joinType:   joinType,
+		table:      table,
+		conditions: conditions,
+	})
+	return db
+}
+
+// JoinSubquery adds an INNER JOIN on a subquery
+func (db *deleteBuilder) JoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder {
+	return db.joinSubquery("INNER", subq, alias, on)
+}
+
+// LeftJoinSubquery adds a LEFT JOIN on a subquery
+func (db *deleteBuilder) LeftJoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder {
+	return db.joinSubquery("LEFT", subq, alias, on)
+}
+
+// RightJoinSubquery adds a RIGHT JOIN on a subquery
+func (db *deleteBuilder) RightJoinSubquery(subq SQLBuilder, alias, on string) DeleteBuilder {
+	return db.joinSubquery("RIGHT", subq, alias, on)
+}
+
+func (db *deleteBuilder) joinSubquery(joinType string, subq SQLBuilder, alias, on string) DeleteBuilder {
+	db.joins = append(db.joins, join{
+		joinType:  joinType,
+		subquery:  &subquery{builder: subq, alias: alias},
+		condition: on,
+	})
+	return db
+}
+
+// Using adds a table the delete reads from, rendered as DELETE FROM t
+// USING a on PostgreSQL and as a multi-table DELETE t FROM t, a on MySQL
+// and SQL Server. Its join condition goes in Where.
+func (db *deleteBuilder) Using(table string) DeleteBuilder {
+	db.using = append(db.using, join{table: table})
+	return db
+}
+
+// UsingSubquery adds a subquery the delete reads from, see Using
+func (db *deleteBuilder) UsingSubquery(subq SQLBuilder, alias string) DeleteBuilder {
+	db.using = append(db.using, join{subquery: &subquery{builder: subq, alias: alias}})
+	return db
+}
+
+// DeleteFrom names the tables or aliases a multi-table delete removes rows
+// from, as in DELETE o, i FROM orders o JOIN items i ON ... on MySQL. It
+// defaults to the deleted table; SQL Server accepts a single target.
+func (db *deleteBuilder) DeleteFrom(targets ...string) DeleteBuilder {
+	db.targets = append(db.targets, targets...)
+	return db
+}
+
+// With adds a common table expression to the WITH clause
+func (db *deleteBuilder) With(alias string, builder SQLBuilder) DeleteBuilder {
+	db.ctes = append(db.ctes, cte{alias: alias, builder: builder})
+	return db
+}
+
+// WithRecursive adds a recursive common table expression to the WITH clause
+func (db *deleteBuilder) WithRecursive(alias string, builder SQLBuilder) DeleteBuilder {
+	db.ctes = append(db.ctes, cte{alias: alias, builder: builder, recursive: true})
+	return db
+}
+
 // From specifies the table to delete from
 func (db *deleteBuilder) From(table string) DeleteBuilder {
 	db.table = table
 	return db
 }
 
+// As sets the alias of the table, rendered as DELETE FROM orders AS o, or
+// as DELETE o FROM orders o on SQL Server and in multi-table deletes
+func (db *deleteBuilder) As(alias string) DeleteBuilder {
+	db.alias = alias
+	return db
+}
+
 // Where adds WHERE conditions
 func (db *deleteBuilder) Where(conditions ...Condition) DeleteBuilder {
 	db.where = append(db.where, conditions...)
 	return db
 }
 
+// WhereIf adds WHERE conditions only when ok is true
+func (db *deleteBuilder) WhereIf(ok bool, conditions ...Condition) DeleteBuilder {
+	if !ok {
+		return db
+	}
+	return db.Where(conditions...)
+}
+
+// WhereGroup adds the conditions collected by fn as a single WHERE
+// condition joined with AND; an empty group adds nothing
+func (db *deleteBuilder) WhereGroup(fn func(g ConditionGroup)) DeleteBuilder {
+	if cond := Group(fn); cond != nil {
+		db.where = append(db.where, cond)
+	}
+	return db
+}
+
+// WalkWhere visits the WHERE conditions with Walk
+func (db *deleteBuilder) WalkWhere(fn func(node ConditionNode) bool) {
+	WalkConditions(db.where, fn)
+}
+
+// RewriteWhere replaces the WHERE conditions with Rewrite, e.g. for
+// middleware narrowing a tenant filter
+func (db *deleteBuilder) RewriteWhere(fn func(node ConditionNode) Condition) DeleteBuilder {
+	db.where = RewriteConditions(db.where, fn)
+	return db
+}
+
 // OrderBy adds ORDER BY clause
 func (db *deleteBuilder) OrderBy(column string, direction string) DeleteBuilder {
 	if direction != "ASC" && direction != "DESC" {
@@ -101,39 +260,111 @@ func (db *deleteBuilder) Limit(limit int) DeleteBuilder {
 	return db
 }
 
+// Comment prefixes the statement with a /* ... */ comment
+func (db *deleteBuilder) Comment(text string) DeleteBuilder {
+	db.comments = append(db.comments, text)
+	return db
+}
+
+// Prefix adds a raw SQL fragment with bound args before the statement
+func (db *deleteBuilder) Prefix(sql string, args ...any) DeleteBuilder {
+	db.prefixes = append(db.prefixes, rawClause{sql: sql, args: args})
+	return db
+}
+
+// Suffix adds a raw SQL fragment with bound args after the statement
+func (db *deleteBuilder) Suffix(sql string, args ...any) DeleteBuilder {
+	db.suffixes = append(db.suffixes, rawClause{sql: sql, args: args})
+	return db
+}
+
 // Returning specifies columns to return after delete
 func (db *deleteBuilder) Returning(columns ...string) DeleteBuilder {
 	db.returning = columns
 	return db
 }
 
+// Clone returns a deep copy of the builder that can be modified independently
+func (db *deleteBuilder) Clone() DeleteBuilder {
+	cloned := *db
+	cloned.where = slices.Clone(db.where)
+	cloned.orderBy = cloneOrders(db.orderBy)
+	cloned.limit = cloneInt(db.limit)
+	cloned.returning = slices.Clone(db.returning)
+	cloned.joins = cloneJoins(db.joins)
+	cloned.using = cloneJoins(db.using)
+	cloned.targets = slices.Clone(db.targets)
+	cloned.ctes = cloneCTEs(db.ctes)
+	cloned.comments = slices.Clone(db.comments)
+	cloned.prefixes = cloneRawClauses(db.prefixes)
+	cloned.suffixes = cloneRawClauses(db.suffixes)
+	return &cloned
+}
+
 // ToSQL generates the SQL query and returns the query and parameters
 func (db *deleteBuilder) ToSQL() (string, []any, error) {
 	if db.table == "" {
 		return "", nil, errors.New("no table specified")
 	}
+	if db.softDelete != "" {
+		ub, err := db.softDeleteUpdate()
+		if err != nil {
+			return "", nil, err
+		}
+		return ub.ToSQL()
+	}
+	if err := checkReturning(db.dialect, db.returning, true); err != nil {
+		return "", nil, err
+	}
+	if err := db.validateMultiTable(); err != nil {
+		return "", nil, err
+	}
 
 	var (
 		query strings.Builder
 		args  []any
 	)
+	db.paramCount = 0
 
-	// DELETE clause
-	query.WriteString("DELETE FROM ")
+	prefixArgs := buildPrefixes(&query, db.prefixes, db.dialect, &db.paramCount)
+	args = append(args, prefixArgs...)
 
-	query.WriteString(db.table)
+	// Comments
+	buildComments(&query, db.comments)
+
+	// WITH clause
+	withArgs, err := buildWithClause(&query, db.ctes, db.dialect, &db.paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, withArgs...)
+
+	// DELETE clause
+	query.WriteString("DELETE ")
+	topSQL, topArgs, err := buildTopClause(db.dialect, db.limit, db.orderBy, &db.paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	query.WriteString(topSQL)
+	args = append(args, topArgs...)
+	usingArgs, err := db.buildDeleteFrom(&query)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, usingArgs...)
 
 	// JOIN clauses
-	for _, j := range db.joins {
-		query.WriteString(fmt.Sprintf(" %s JOIN %s ON %s",
-			j.joinType,
-			j.table,
-			j.condition,
-		))
+	joinArgs, err := db.buildJoinClauses(&query)
+	if err != nil {
+		return "", nil, err
 	}
+	args = append(args, joinArgs...)
 
 	// WHERE clause
-	whereSQL, whereArgs := db.buildWhereClause()
+	whereSQL, whereArgs, err := db.buildWhereClause()
+	if err != nil {
+		return "", nil, err
+	}
 	if whereSQL != "" {
 		query.WriteString(" WHERE ")
 		query.WriteString(whereSQL)
@@ -159,16 +390,223 @@ func (db *deleteBuilder) ToSQL() (string, []any, error) {
 		query.WriteString(returningSQL)
 	}
 
+	suffixArgs := buildSuffixes(&query, db.suffixes, db.dialect, &db.paramCount)
+	args = append(args, suffixArgs...)
+
 	return query.String(), args, nil
 }
 
+// buildJoinClauses builds the JOIN clauses. Joined tables and subquery
+// aliases are escaped like every name the delete writes; ON strings are
+// written as given. PostgreSQL has no DELETE ... JOIN, so there the joins
+// are rendered by buildDeleteFrom and buildWhereClause instead.
+func (db *deleteBuilder) buildJoinClauses(query *strings.Builder) ([]any, error) {
+	if _, ok := db.dialect.(postgresDialect); ok {
+		return nil, nil
+	}
+	var args []any
+	for _, j := range db.joins {
+		query.WriteString(fmt.Sprintf(" %s JOIN ", j.joinType))
+		sourceArgs, err := db.buildJoinSource(query, j)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, sourceArgs...)
+		query.WriteString(" ON ")
+		onSQL, onArgs, err := db.buildJoinCondition(j)
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(onSQL)
+		args = append(args, onArgs...)
+	}
+	return args, nil
+}
+
+// buildJoinSource writes the joined table or subquery with its alias
+func (db *deleteBuilder) buildJoinSource(query *strings.Builder, j join) ([]any, error) {
+	if j.subquery == nil {
+		query.WriteString(escapeTableRef(db.dialect, j.table))
+		return nil, nil
+	}
+	subSQL, subArgs, err := j.subquery.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	query.WriteString(shiftPlaceholders(subSQL, db.dialect, db.paramCount))
+	if j.subquery.alias != "" {
+		query.WriteString(" AS ")
+		query.WriteString(escapeIdentifier(db.dialect, j.subquery.alias))
+	}
+	db.paramCount += len(subArgs)
+	return subArgs, nil
+}
+
+// buildJoinCondition renders the ON clause of a join
+func (db *deleteBuilder) buildJoinCondition(j join) (string, []any, error) {
+	if len(j.conditions) > 0 {
+		return buildConditions(j.conditions, db.dialect, &db.paramCount)
+	}
+	return j.condition, nil, nil
+}
+
+// isMultiTable reports whether the delete renders as a multi-table delete:
+// USING on PostgreSQL, DELETE target FROM ... on MySQL and SQL Server. SQL
+// Server also needs that form to alias the table.
+func (db *deleteBuilder) isMultiTable() bool {
+	if _, ok := db.dialect.(sqlserverDialect); ok && db.alias != "" {
+		return true
+	}
+	return len(db.using) > 0 || len(db.joins) > 0 || len(db.targets) > 0
+}
+
+// validateMultiTable checks that the dialect can render the Using tables
+// and DeleteFrom targets
+func (db *deleteBuilder) validateMultiTable() error {
+	if !db.isMultiTable() {
+		return nil
+	}
+	switch db.dialect.(type) {
+	case postgresDialect:
+		if len(db.targets) > 0 {
+			return errors.New("DeleteFrom is only supported by MySQL and SQL Server")
+		}
+		for _, j := range db.joins {
+			if j.joinType != "INNER" {
+				return errors.New("PostgreSQL deletes can only join with INNER JOIN")
+			}
+		}
+	case sqlserverDialect:
+		if len(db.targets) > 1 {
+			return errors.New("SQL Server deletes from a single target")
+		}
+	case mysqlDialect:
+		if len(db.orderBy) > 0 || db.limit != nil {
+			return errors.New("multi-table DELETE cannot use ORDER BY or LIMIT")
+		}
+	default:
+		if len(db.targets) > 0 {
+			return errors.New("DeleteFrom is only supported by MySQL and SQL Server")
+		}
+		return errors.New("DELETE with USING or JOIN is only supported by PostgreSQL, MySQL and SQL Server")
+	}
+	return nil
+}
+
+// deleteTargets returns the DeleteFrom targets, defaulting to the alias of
+// the table or the table itself when it has no alias
+func (db *deleteBuilder) deleteTargets() string {
+	if len(db.targets) > 0 {
+		targets := make([]string, len(db.targets))
+		for i, target := range db.targets {
+			targets[i] = escapeIdentifier(db.dialect, target)
+		}
+		return strings.Join(targets, ", ")
+	}
+	if db.alias != "" {
+		return escapeIdentifier(db.dialect, db.alias)
+	}
+	fields := strings.Fields(db.table)
+	if len(fields) == 0 {
+		return db.table
+	}
+	return escapeIdentifier(db.dialect, fields[len(fields)-1])
+}
+
+// tableRef returns the deleted table with its alias, both escaped as the
+// select builder's FromAs does: "orders o" in multi-table deletes and on
+// Oracle, "orders AS o" otherwise
+func (db *deleteBuilder) tableRef() string {
+	if db.alias == "" {
+		return escapeTableRef(db.dialect, db.table)
+	}
+	table, alias := escapeIdentifier(db.dialect, db.table), escapeIdentifier(db.dialect, db.alias)
+	if _, ok := db.dialect.(oracleDialect); ok || db.isMultiTable() {
+		return table + " " + alias
+	}
+	return table + " AS " + alias
+}
+
+// buildDeleteFrom writes the FROM clause with the Using tables: USING on
+// PostgreSQL, followed by the joined tables whose ON predicates
+// buildWhereClause adds, otherwise a multi-table delete naming its targets
+func (db *deleteBuilder) buildDeleteFrom(query *strings.Builder) ([]any, error) {
+	if !db.isMultiTable() {
+		query.WriteString("FROM ")
+		query.WriteString(db.tableRef())
+		query.WriteString(db.buildOutputClause())
+		return nil, nil
+	}
+
+	if _, ok := db.dialect.(postgresDialect); ok {
+		query.WriteString("FROM " + db.tableRef() + " USING ")
+	} else {
+		query.WriteString(db.deleteTargets() + db.buildOutputClause() + " FROM " + db.tableRef())
+		if len(db.using) > 0 {
+			query.WriteString(", ")
+		}
+	}
+
+	var args []any
+	for i, u := range db.using {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		if u.subquery == nil {
+			query.WriteString(escapeTableRef(db.dialect, u.table))
+			continue
+		}
+		subSQL, subArgs, err := u.subquery.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(shiftPlaceholders(subSQL, db.dialect, db.paramCount))
+		query.WriteString(" AS ")
+		query.WriteString(escapeIdentifier(db.dialect, u.subquery.alias))
+		args = append(args, subArgs...)
+		db.paramCount += len(subArgs)
+	}
+	if _, ok := db.dialect.(postgresDialect); ok {
+		for i, j := range db.joins {
+			if i > 0 || len(db.using) > 0 {
+				query.WriteString(", ")
+			}
+			sourceArgs, err := db.buildJoinSource(query, j)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, sourceArgs...)
+		}
+	}
+	return args, nil
+}
+
 // buildWhereClause builds the WHERE clause and returns the SQL and arguments.
-func (db *deleteBuilder) buildWhereClause() (string, []any) {
-	if len(db.where) == 0 {
-		return "", nil
+// On PostgreSQL the ON predicates of the joins come first.
+func (db *deleteBuilder) buildWhereClause() (string, []any, error) {
+	var (
+		parts []string
+		args  []any
+	)
+	if _, ok := db.dialect.(postgresDialect); ok {
+		for _, j := range db.joins {
+			onSQL, onArgs, err := db.buildJoinCondition(j)
+			if err != nil {
+				return "", nil, err
+			}
+			parts = append(parts, "("+onSQL+")")
+			args = append(args, onArgs...)
+		}
 	}
-	whereSQL, whereArgs := buildConditions(db.where, db.dialect, &db.paramCount)
-	return whereSQL, whereArgs
+	if len(db.where) > 0 {
+		whereSQL, whereArgs, err := buildConditions(db.where, db.dialect, &db.paramCount)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, whereSQL)
+		args = append(args, whereArgs...)
+	}
+	return strings.Join(parts, " AND "), args, nil
 }
 
 // buildOrderByClause builds the ORDER BY clause if supported by the dialect.
@@ -210,23 +648,38 @@ func (db *deleteBuilder) buildLimitClause() (string, []any) {
 	}
 }
 
+// buildOutputClause builds SQL Server's OUTPUT DELETED clause standing in
+// for RETURNING. Qualified columns lose their table alias.
+func (db *deleteBuilder) buildOutputClause() string {
+	if _, ok := db.dialect.(sqlserverDialect); !ok || len(db.returning) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(" OUTPUT ")
+	for i, col := range db.returning {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if dot := strings.LastIndex(col, "."); dot >= 0 {
+			col = col[dot+1:]
+		}
+		sb.WriteString("DELETED." + col)
+	}
+	return sb.String()
+}
+
 // buildReturningClause builds the RETURNING clause if supported by the dialect.
 func (db *deleteBuilder) buildReturningClause() string {
-	if len(db.returning) == 0 {
+	if len(db.returning) == 0 || !supportsReturning(db.dialect) {
 		return ""
 	}
-	switch db.dialect.(type) {
-	case postgresDialect, sqliteDialect:
-		var sb strings.Builder
-		sb.WriteString(" RETURNING ")
-		for i, col := range db.returning {
-			if i > 0 {
-				sb.WriteString(", ")
-			}
-			sb.WriteString(col)
+	var sb strings.Builder
+	sb.WriteString(" RETURNING ")
+	for i, col := range db.returning {
+		if i > 0 {
+			sb.WriteString(", ")
 		}
-		return sb.String()
-	default:
-		return ""
+		sb.WriteString(col)
 	}
+	return sb.String()
 }
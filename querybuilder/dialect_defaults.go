@@ -1,6 +1,7 @@
 package querybuilder
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -16,6 +17,10 @@ func (d baseDialect) EscapeString(value string) string {
 	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
 }
 
+func (d baseDialect) EscapeIdentifier(name string) string {
+	return quoteIdentifier(name, `"`, `"`)
+}
+
 // --------------------------
 // MySQL Dialect
 // --------------------------
@@ -24,6 +29,10 @@ type mysqlDialect struct {
 	baseDialect
 }
 
+func (d mysqlDialect) EscapeIdentifier(name string) string {
+	return quoteIdentifier(name, "`", "`")
+}
+
 func (d mysqlDialect) Placeholder(index int) string {
 	var query strings.Builder
 	query.Write([]byte("?"))
@@ -66,6 +75,10 @@ type sqlserverDialect struct {
 	baseDialect
 }
 
+func (d sqlserverDialect) EscapeIdentifier(name string) string {
+	return quoteIdentifier(name, "[", "]")
+}
+
 func (d sqlserverDialect) Placeholder(index int) string {
 	var query strings.Builder
 	query.Write([]byte(fmt.Sprintf("@p%d", index+1)))
@@ -78,6 +91,25 @@ func (d sqlserverDialect) Placeholder(index int) string {
 
 type oracleDialect struct {
 	baseDialect
+	rowNumPagination bool
+}
+
+// OracleOption configures the Oracle dialect
+type OracleOption func(*oracleDialect)
+
+// WithRowNumPagination makes the Oracle dialect paginate by wrapping the query
+// in ROWNUM subqueries instead of OFFSET / FETCH, for servers older than 12c
+func WithRowNumPagination() OracleOption {
+	return func(d *oracleDialect) {
+		d.rowNumPagination = true
+	}
+}
+
+// EscapeIdentifier leaves Oracle identifiers as written: quoting makes them
+// case-sensitive, so a quoted "people" would no longer match the PEOPLE
+// table or the unquoted references in the rest of the statement
+func (d oracleDialect) EscapeIdentifier(name string) string {
+	return name
 }
 
 func (d oracleDialect) Placeholder(index int) string {
@@ -86,6 +118,134 @@ func (d oracleDialect) Placeholder(index int) string {
 	return query.String()
 }
 
+// --------------------------
+// Dialect Capabilities
+// --------------------------
+
+// qualifySupporter is implemented by dialects with a native QUALIFY clause
+// (e.g. Snowflake, BigQuery, DuckDB)
+type qualifySupporter interface {
+	SupportsQualify() bool
+}
+
+// supportsQualify reports whether the dialect has a native QUALIFY clause
+func supportsQualify(dialect Dialect) bool {
+	q, ok := dialect.(qualifySupporter)
+	return ok && q.SupportsQualify()
+}
+
+// returningSupporter is implemented by dialects that override whether DML
+// statements accept a RETURNING clause (e.g. MariaDB 10.5+)
+type returningSupporter interface {
+	SupportsReturning() bool
+}
+
+// supportsReturning reports whether the dialect has a RETURNING clause on
+// INSERT, UPDATE and DELETE. PostgreSQL and SQLite (3.35+) do.
+func supportsReturning(dialect Dialect) bool {
+	if r, ok := dialect.(returningSupporter); ok {
+		return r.SupportsReturning()
+	}
+	switch dialect.(type) {
+	case postgresDialect, sqliteDialect:
+		return true
+	}
+	return false
+}
+
+// checkReturning returns an error when the statement asks for returned rows
+// that the dialect cannot produce. output reports whether the statement
+// falls back to SQL Server's OUTPUT clause.
+func checkReturning(dialect Dialect, returning []string, output bool) error {
+	if len(returning) == 0 || supportsReturning(dialect) {
+		return nil
+	}
+	if _, ok := dialect.(sqlserverDialect); ok && output {
+		return nil
+	}
+	return errors.New("RETURNING is not supported by this dialect")
+}
+
+// --------------------------
+// Identifier Escaping
+// --------------------------
+
+// identifierEscaper is implemented by dialects that can quote identifiers
+type identifierEscaper interface {
+	EscapeIdentifier(name string) string
+}
+
+// escapeIdentifier quotes an identifier when the dialect supports it
+func escapeIdentifier(dialect Dialect, name string) string {
+	if e, ok := dialect.(identifierEscaper); ok {
+		return e.EscapeIdentifier(name)
+	}
+	return name
+}
+
+// escapeColumnRef quotes a plain, possibly qualified column reference and
+// returns expressions such as LOWER(a.email) or price * qty unchanged
+func escapeColumnRef(dialect Dialect, ref string) string {
+	if !isColumnRef(ref) {
+		return ref
+	}
+	return escapeIdentifier(dialect, ref)
+}
+
+// escapeTableRef quotes a table reference of the form "table", "table alias"
+// or "table AS alias", keeping the alias separate from the table name. Every
+// builder writes its tables through it; references of any other shape, and
+// names the caller already quoted, are returned unchanged.
+func escapeTableRef(dialect Dialect, ref string) string {
+	parts := strings.Fields(ref)
+	if len(parts) == 0 || !isColumnRef(parts[0]) || !isColumnRef(parts[len(parts)-1]) {
+		return ref
+	}
+	switch {
+	case len(parts) == 1:
+		return escapeIdentifier(dialect, parts[0])
+	case len(parts) == 2:
+		return escapeIdentifier(dialect, parts[0]) + " " + escapeIdentifier(dialect, parts[1])
+	case len(parts) == 3 && strings.EqualFold(parts[1], "AS"):
+		return escapeIdentifier(dialect, parts[0]) + " AS " + escapeIdentifier(dialect, parts[2])
+	default:
+		return ref
+	}
+}
+
+// quoteIdentifier quotes every part of a possibly qualified identifier
+// (schema.table.column). Parts that are "*" or already quoted are kept as is.
+func quoteIdentifier(name, open, close string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" || part == "" || strings.HasPrefix(part, open) {
+			continue
+		}
+		parts[i] = open + strings.ReplaceAll(part, close, close+close) + close
+	}
+	return strings.Join(parts, ".")
+}
+
+// DialectName returns the name of a built-in dialect: "mysql", "postgres",
+// "sqlite", "sqlserver" or "oracle", and "" for other dialects. It lets
+// packages built on the query builder pick dialect-specific SQL.
+func DialectName(dialect Dialect) string {
+	switch dialect.(type) {
+	case mysqlDialect:
+		return "mysql"
+	case postgresDialect:
+		return "postgres"
+	case sqliteDialect:
+		return "sqlite"
+	case sqlserverDialect:
+		return "sqlserver"
+	case oracleDialect:
+		return "oracle"
+	default:
+		return ""
+	}
+}
+
 // --------------------------
 // Factory Functions
 // --------------------------
@@ -106,6 +266,10 @@ func NewSQLServerDialect() Dialect {
 	return sqlserverDialect{}
 }
 
-func NewOracleDialect() Dialect {
-	return oracleDialect{}
+func NewOracleDialect(opts ...OracleOption) Dialect {
+	d := oracleDialect{}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
 }
@@ -0,0 +1,121 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// DropBuilder builds DROP TABLE, DROP INDEX and DROP VIEW statements
+type DropBuilder interface {
+	IfExists() DropBuilder
+	Cascade() DropBuilder
+	ToSQL() (string, []any, error)
+}
+
+// dropBuilder implements DropBuilder
+type dropBuilder struct {
+	dialect  Dialect
+	kind     string // TABLE, INDEX or VIEW
+	name     string
+	table    string // table of a dropped index, required by MySQL and SQL Server
+	ifExists bool
+	cascade  bool
+}
+
+// DropTable begins a DROP TABLE statement
+func (qb *QueryBuilder) DropTable(table string) DropBuilder {
+	return &dropBuilder{dialect: qb.dialect, kind: "TABLE", name: table}
+}
+
+// DropIndex begins a DROP INDEX statement. The table is only written on
+// MySQL and SQL Server, which require it.
+func (qb *QueryBuilder) DropIndex(name, table string) DropBuilder {
+	return &dropBuilder{dialect: qb.dialect, kind: "INDEX", name: name, table: table}
+}
+
+// DropView begins a DROP VIEW statement
+func (qb *QueryBuilder) DropView(view string) DropBuilder {
+	return &dropBuilder{dialect: qb.dialect, kind: "VIEW", name: view}
+}
+
+// IfExists makes the statement a no-op when the object doesn't exist. Not
+// supported by Oracle, nor by MySQL for indexes.
+func (db *dropBuilder) IfExists() DropBuilder {
+	db.ifExists = true
+	return db
+}
+
+// Cascade also drops the objects depending on the dropped one: CASCADE on
+// PostgreSQL and MySQL, CASCADE CONSTRAINTS on Oracle. Indexes only
+// cascade on PostgreSQL.
+func (db *dropBuilder) Cascade() DropBuilder {
+	db.cascade = true
+	return db
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (db *dropBuilder) ToSQL() (string, []any, error) {
+	if db.name == "" {
+		return "", nil, errors.New("no " + strings.ToLower(db.kind) + " specified")
+	}
+	if err := db.validate(); err != nil {
+		return "", nil, err
+	}
+
+	var query strings.Builder
+	query.WriteString("DROP " + db.kind + " ")
+	if db.ifExists {
+		query.WriteString("IF EXISTS ")
+	}
+	if db.kind == "TABLE" {
+		query.WriteString(escapeTableRef(db.dialect, db.name))
+	} else {
+		query.WriteString(db.name)
+	}
+	if db.kind == "INDEX" {
+		switch db.dialect.(type) {
+		case mysqlDialect, sqlserverDialect:
+			query.WriteString(" ON " + escapeTableRef(db.dialect, db.table))
+		}
+	}
+	if db.cascade {
+		if _, ok := db.dialect.(oracleDialect); ok {
+			query.WriteString(" CASCADE CONSTRAINTS")
+		} else {
+			query.WriteString(" CASCADE")
+		}
+	}
+	return query.String(), nil, nil
+}
+
+// validate checks that the dialect supports the requested drop options
+func (db *dropBuilder) validate() error {
+	if db.kind == "INDEX" && db.table == "" {
+		switch db.dialect.(type) {
+		case mysqlDialect, sqlserverDialect:
+			return errors.New("DROP INDEX requires the table on MySQL and SQL Server")
+		}
+	}
+	switch db.dialect.(type) {
+	case postgresDialect:
+	case mysqlDialect:
+		if db.cascade && db.kind == "INDEX" {
+			return errors.New("DROP INDEX ... CASCADE is not supported by MySQL")
+		}
+		if db.ifExists && db.kind == "INDEX" {
+			return errors.New("DROP INDEX IF EXISTS is not supported by MySQL")
+		}
+	case oracleDialect:
+		if db.ifExists {
+			return errors.New("DROP ... IF EXISTS is not supported by Oracle")
+		}
+		if db.cascade && db.kind == "INDEX" {
+			return errors.New("DROP INDEX ... CASCADE is not supported by Oracle")
+		}
+	default:
+		if db.cascade {
+			return errors.New("DROP ... CASCADE is only supported by PostgreSQL, MySQL and Oracle")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Queryer executes statements; *sql.DB, *sql.Tx and *sql.Conn implement it
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ExecReturningID runs the insert and returns the generated key of the
+// first inserted row. The key column is the first Returning column, or
+// "id". It uses RETURNING on PostgreSQL and SQLite, OUTPUT on SQL Server,
+// LastInsertId on MySQL and RETURNING ... INTO on Oracle, which only
+// supports single-row inserts here.
+func (ib *insertBuilder) ExecReturningID(ctx context.Context, db Queryer) (int64, error) {
+	idColumn := "id"
+	if len(ib.returning) > 0 {
+		idColumn = ib.returning[0]
+	}
+
+	var id int64
+	switch ib.dialect.(type) {
+	case mysqlDialect:
+		insert := ib.Clone().(*insertBuilder)
+		insert.returning = nil
+		query, args, err := insert.ToSQL()
+		if err != nil {
+			return 0, err
+		}
+		res, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		return res.LastInsertId()
+
+	case oracleDialect:
+		if len(ib.values) != 1 || ib.conflict != nil {
+			return 0, errors.New("oracle RETURNING INTO requires a single-row insert without upsert")
+		}
+		insert := ib.Clone().(*insertBuilder)
+		insert.returning = nil
+		insert.Suffix("RETURNING "+idColumn+" INTO ?", sql.Out{Dest: &id})
+		query, args, err := insert.ToSQL()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return 0, err
+		}
+		return id, nil
+
+	default:
+		insert := ib.Clone().(*insertBuilder)
+		insert.returning = []string{idColumn}
+		query, args, err := insert.ToSQL()
+		if err != nil {
+			return 0, err
+		}
+		if err := db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+}
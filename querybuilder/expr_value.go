@@ -0,0 +1,66 @@
+package querybuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// exprValue is a SQL expression with bound args used in place of a value
+type exprValue struct {
+	sql  string
+	args []any
+}
+
+// ExprValue creates a value expression such as ExprValue("LOWER(?)", email)
+// for insert Values, update Set and ConflictAction.DoUpdate. Its "?"
+// placeholders are bound to args in order; raw SQL args (Raw, UnsafeRaw)
+// are written in place of their "?" instead.
+func ExprValue(sql string, args ...any) any {
+	return exprValue{sql: sql, args: slices.Clone(args)}
+}
+
+// toSQL renders the expression, numbering its placeholders from paramCount.
+// It fails when the number of placeholders and args differ.
+func (e exprValue) toSQL(dialect Dialect, paramCount *int) (string, []any, error) {
+	var (
+		sql      strings.Builder
+		args     []any
+		next     int
+		inString bool
+	)
+
+	for _, r := range e.sql {
+		switch {
+		case r == '\'':
+			inString = !inString
+			sql.WriteRune(r)
+		case r == '?' && !inString:
+			if next >= len(e.args) {
+				return "", nil, fmt.Errorf("expression %q has more placeholders than args (%d)", e.sql, len(e.args))
+			}
+			args = appendValue(&sql, e.args[next], args, dialect, paramCount)
+			next++
+		default:
+			sql.WriteRune(r)
+		}
+	}
+	if next != len(e.args) {
+		return "", nil, fmt.Errorf("expression %q has %d placeholders but %d args", e.sql, next, len(e.args))
+	}
+	return sql.String(), args, nil
+}
+
+// writeValue writes a value of an insert or update: raw SQL inline, an
+// ExprValue with its bound args, or a placeholder for anything else
+func writeValue(sql *strings.Builder, val any, dialect Dialect, paramCount *int) ([]any, error) {
+	if expr, ok := val.(exprValue); ok {
+		exprSQL, exprArgs, err := expr.toSQL(dialect, paramCount)
+		if err != nil {
+			return nil, err
+		}
+		sql.WriteString(exprSQL)
+		return exprArgs, nil
+	}
+	return appendValue(sql, val, nil, dialect, paramCount), nil
+}
@@ -0,0 +1,146 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// filterOperators maps the operators accepted in `filter` struct tags to
+// their condition constructors
+var filterOperators = map[string]func(column string, value any) NegatableCondition{
+	"eq":    Eq,
+	"ne":    NotEq,
+	"gt":    Gt,
+	"gte":   GtOrEq,
+	"lt":    Lt,
+	"lte":   LtOrEq,
+	"like":  Like,
+	"ilike": ILike,
+	"in": func(column string, value any) NegatableCondition {
+		return In(column, value)
+	},
+	"notin": func(column string, value any) NegatableCondition {
+		return NotIn(column, value)
+	},
+}
+
+// ConditionsFromStruct builds conditions from the `filter` tags of a struct
+// (or pointer to struct), e.g.
+//
+//	type PeopleFilter struct {
+//		MinAge int      `filter:"age,gte"`
+//		Name   string   `filter:"full_name,like"`
+//		IDs    []int64  `filter:"id,in"`
+//		Status *string  `filter:"status"`
+//	}
+//
+// The operator defaults to eq. Fields with a zero value (nil pointers, empty
+// strings and slices, ...) are skipped, so a pointer is needed to filter on
+// a zero value. Untagged fields and fields tagged "-" are ignored, and
+// embedded structs are walked.
+func ConditionsFromStruct(v any) ([]Condition, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("filter must be a struct or a pointer to a struct")
+	}
+	return conditionsFromStruct(rv)
+}
+
+func conditionsFromStruct(rv reflect.Value) ([]Condition, error) {
+	var conditions []Condition
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		value := rv.Field(i)
+		tag, tagged := field.Tag.Lookup("filter")
+
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded, err := conditionsFromStruct(value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, embedded...)
+			continue
+		}
+		if !tagged || tag == "-" || !field.IsExported() || value.IsZero() {
+			continue
+		}
+
+		column, op, _ := strings.Cut(tag, ",")
+		if column == "" {
+			return nil, fmt.Errorf("missing column in filter tag of field %s", field.Name)
+		}
+		if op == "" {
+			op = "eq"
+		}
+		build, ok := filterOperators[op]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter operator %q on field %s", op, field.Name)
+		}
+
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Slice && value.Len() == 0 {
+			continue
+		}
+		conditions = append(conditions, build(column, value.Interface()))
+	}
+	return conditions, nil
+}
+
+// mapOperators maps the operator suffixes accepted in ConditionsFromMap keys
+// to their condition constructors
+var mapOperators = map[string]func(column string, value any) NegatableCondition{
+	"=":         Eq,
+	"<>":        NotEq,
+	"!=":        NotEq,
+	">":         Gt,
+	">=":        GtOrEq,
+	"<":         Lt,
+	"<=":        LtOrEq,
+	"LIKE":      Like,
+	"NOT LIKE":  NotLike,
+	"ILIKE":     ILike,
+	"NOT ILIKE": NotILike,
+	"IN":        filterOperators["in"],
+	"NOT IN":    filterOperators["notin"],
+	"IS": func(column string, _ any) NegatableCondition {
+		return IsNull(column)
+	},
+	"IS NOT": func(column string, _ any) NegatableCondition {
+		return IsNotNull(column)
+	},
+}
+
+// ConditionsFromMap builds conditions from a map whose keys are a column
+// optionally followed by an operator, e.g. "age >=", "name LIKE" or
+// "status IN". A bare column means equality, and "IS" / "IS NOT" keys
+// produce IS NULL / IS NOT NULL whatever their value. Conditions are
+// returned sorted by key so the generated SQL is stable.
+func ConditionsFromMap(m map[string]any) ([]Condition, error) {
+	conditions := make([]Condition, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		column, op, _ := strings.Cut(strings.TrimSpace(key), " ")
+		op = strings.ToUpper(strings.Join(strings.Fields(op), " "))
+		if op == "" {
+			op = "="
+		}
+		build, ok := mapOperators[op]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %q in condition key %q", op, key)
+		}
+		conditions = append(conditions, build(column, m[key]))
+	}
+	return conditions, nil
+}
@@ -0,0 +1,148 @@
+// Package filters parses REST-style query string filters and sorting into
+// querybuilder conditions and orderings, restricted to an allowlist of
+// columns per endpoint:
+//
+//	?filter=age:gte:10&filter=name:like:arif&sort=-created_at,name
+package filters
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// Parser parses filters and sorting for one endpoint
+type Parser struct {
+	columns  map[string]string
+	sortable map[string]string
+}
+
+// Sort is a single ORDER BY column parsed from the sort parameter
+type Sort struct {
+	Column    string
+	Direction string // "ASC" or "DESC"
+}
+
+// Result holds the conditions and sorting parsed from a query string
+type Result struct {
+	Conditions []querybuilder.Condition
+	Sorts      []Sort
+}
+
+// NewParser creates a parser accepting the given fields. columns maps the
+// public field names used in the query string to the SQL columns they
+// filter, so clients never name columns directly. Every filterable field is
+// also sortable unless Sortable restricts it.
+func NewParser(columns map[string]string) *Parser {
+	return &Parser{columns: columns, sortable: columns}
+}
+
+// Sortable restricts sorting to the given public field names
+func (p *Parser) Sortable(fields ...string) *Parser {
+	p.sortable = make(map[string]string, len(fields))
+	for _, field := range fields {
+		if column, ok := p.columns[field]; ok {
+			p.sortable[field] = column
+		}
+	}
+	return p
+}
+
+// Parse reads the filter and sort parameters. Each filter is
+// field:operator:value, where operator is one of eq, ne, gt, gte, lt, lte,
+// like (substring match, wildcards escaped), in (comma-separated values),
+// null or notnull (no value). Sort fields are comma-separated or repeated,
+// and prefixed with "-" for descending order.
+func (p *Parser) Parse(values url.Values) (Result, error) {
+	var result Result
+
+	for _, filter := range values["filter"] {
+		cond, err := p.parseFilter(filter)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Conditions = append(result.Conditions, cond)
+	}
+
+	for _, param := range values["sort"] {
+		for _, field := range strings.Split(param, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			direction := "ASC"
+			if strings.HasPrefix(field, "-") {
+				direction = "DESC"
+				field = field[1:]
+			}
+			column, ok := p.sortable[field]
+			if !ok {
+				return Result{}, fmt.Errorf("sorting by %q is not allowed", field)
+			}
+			result.Sorts = append(result.Sorts, Sort{Column: column, Direction: direction})
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Parser) parseFilter(filter string) (querybuilder.Condition, error) {
+	parts := strings.SplitN(filter, ":", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid filter %q, expected field:operator:value", filter)
+	}
+	field, op := parts[0], strings.ToLower(parts[1])
+	column, ok := p.columns[field]
+	if !ok {
+		return nil, fmt.Errorf("filtering by %q is not allowed", field)
+	}
+
+	switch op {
+	case "null":
+		return querybuilder.IsNull(column), nil
+	case "notnull":
+		return querybuilder.IsNotNull(column), nil
+	}
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("missing value in filter %q", filter)
+	}
+	value := parts[2]
+
+	switch op {
+	case "eq":
+		return querybuilder.Eq(column, value), nil
+	case "ne":
+		return querybuilder.NotEq(column, value), nil
+	case "gt":
+		return querybuilder.Gt(column, value), nil
+	case "gte":
+		return querybuilder.GtOrEq(column, value), nil
+	case "lt":
+		return querybuilder.Lt(column, value), nil
+	case "lte":
+		return querybuilder.LtOrEq(column, value), nil
+	case "like":
+		return querybuilder.Contains(column, value), nil
+	case "in":
+		var list []any
+		for _, v := range strings.Split(value, ",") {
+			list = append(list, v)
+		}
+		return querybuilder.In(column, list...), nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q in filter %q", op, filter)
+	}
+}
+
+// Apply adds the parsed conditions and sorting to a select query
+func (r Result) Apply(sb querybuilder.SelectBuilder) querybuilder.SelectBuilder {
+	if len(r.Conditions) > 0 {
+		sb = sb.Where(r.Conditions...)
+	}
+	for _, s := range r.Sorts {
+		sb = sb.OrderBy(s.Column, s.Direction)
+	}
+	return sb
+}
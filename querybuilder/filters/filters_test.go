@@ -0,0 +1,86 @@
+package filters
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+func TestParse(t *testing.T) {
+	parser := NewParser(map[string]string{
+		"age":     "p.age",
+		"name":    "p.full_name",
+		"status":  "p.status",
+		"created": "p.created_at",
+	}).Sortable("age", "created")
+
+	tests := []struct {
+		name     string
+		query    string
+		isError  bool
+		expected string
+		args     []any
+	}{
+		{
+			name:  "Filters and Sort",
+			query: "filter=age:gte:10&filter=name:like:ar_if&filter=status:in:active,pending&sort=-created,age",
+			expected: `SELECT p.id FROM "people" "p" WHERE p.age >= $1 AND p.full_name LIKE $2 ESCAPE '!' AND p.status IN ($3, $4) ` +
+				"ORDER BY p.created_at DESC, p.age ASC",
+			args: []any{"10", "%ar!_if%", "active", "pending"},
+		},
+		{
+			name:     "Like with Wildcards",
+			query:    "filter=name:like:100%25!",
+			expected: `SELECT p.id FROM "people" "p" WHERE p.full_name LIKE $1 ESCAPE '!'`,
+			args:     []any{"%100!%!!%"},
+		},
+		{
+			name:     "Null Filter",
+			query:    "filter=created:notnull",
+			expected: `SELECT p.id FROM "people" "p" WHERE p.created_at IS NOT NULL`,
+		},
+		{
+			name:    "Filter on Unknown Field",
+			query:   "filter=password:eq:secret",
+			isError: true,
+		},
+		{
+			name:    "Sort on Unsortable Field",
+			query:   "sort=name",
+			isError: true,
+		},
+		{
+			name:    "Unknown Operator",
+			query:   "filter=age:between:1",
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := parser.Parse(values)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			sb := querybuilder.New().WithDialect(querybuilder.NewPostgreSQLDialect()).Select("p.id").From("people p")
+			query, args, err := result.Apply(sb).ToSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected || !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("expected %q %v, got %q %v", tt.expected, tt.args, query, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package querybuilder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Fragment is a reusable SQL predicate with its args, such as a shared
+// business rule, that can be embedded in the WHERE, HAVING or JOIN ON
+// conditions of any number of builders:
+//
+//	var activeCustomer = NewFragment("status = ? AND deleted_at IS NULL", "active")
+//
+// Its "?" placeholders are renumbered for the dialect and position of each
+// statement it is embedded in. An arg that is itself a Condition (another
+// Fragment, Eq, ...) is rendered in place of its "?" so fragments compose.
+type Fragment struct {
+	sql  string
+	args []any
+}
+
+// NewFragment creates a fragment. The args are copied, so later changes to
+// the caller's slice do not affect it.
+func NewFragment(sql string, args ...any) Fragment {
+	return Fragment{sql: sql, args: slices.Clone(args)}
+}
+
+// SQL returns the fragment SQL with its "?" placeholders
+func (f Fragment) SQL() string {
+	return f.sql
+}
+
+// Args returns a copy of the fragment args
+func (f Fragment) Args() []any {
+	return slices.Clone(f.args)
+}
+
+// ToSQL renders the fragment as a condition
+func (f Fragment) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := f.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the fragment, parenthesized so it combines safely with
+// other conditions. It fails when the number of placeholders and args differ.
+func (f Fragment) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
+	var (
+		sql      strings.Builder
+		args     []any
+		next     int
+		inString bool
+	)
+
+	sql.WriteString("(")
+	for _, r := range f.sql {
+		switch {
+		case r == '\'':
+			inString = !inString
+			sql.WriteRune(r)
+		case r == '?' && !inString:
+			if next >= len(f.args) {
+				return "", nil, fmt.Errorf("fragment %q has more placeholders than args (%d)", f.sql, len(f.args))
+			}
+			arg := f.args[next]
+			next++
+			if cond, ok := arg.(Condition); ok {
+				condSQL, condArgs, err := conditionToSQL(cond, dialect, argPos)
+				if err != nil {
+					return "", nil, err
+				}
+				sql.WriteString(condSQL)
+				args = append(args, condArgs...)
+				continue
+			}
+			sql.WriteString(dialect.Placeholder(*argPos))
+			*argPos++
+			args = append(args, arg)
+		default:
+			sql.WriteRune(r)
+		}
+	}
+	sql.WriteString(")")
+
+	if next != len(f.args) {
+		return "", nil, fmt.Errorf("fragment %q has %d placeholders but %d args", f.sql, next, len(f.args))
+	}
+	return sql.String(), args, nil
+}
@@ -0,0 +1,69 @@
+package querybuilder
+
+// ConditionGroup collects conditions imperatively, e.g. inside loops,
+// for WhereGroup and Group. Conditions added with Where are joined with the
+// group's operator: AND for the top-level group and AndGroup, OR for OrGroup.
+type ConditionGroup interface {
+	Where(conditions ...Condition) ConditionGroup
+	Or(conditions ...Condition) ConditionGroup
+	AndGroup(fn func(g ConditionGroup)) ConditionGroup
+	OrGroup(fn func(g ConditionGroup)) ConditionGroup
+}
+
+// conditionGroup implements ConditionGroup
+type conditionGroup struct {
+	operator   string // "AND", "OR"
+	conditions []Condition
+}
+
+// Group builds a condition from a closure, joining what it adds with AND.
+// It returns nil when the closure adds nothing.
+func Group(fn func(g ConditionGroup)) Condition {
+	return buildGroup("AND", fn)
+}
+
+func buildGroup(operator string, fn func(g ConditionGroup)) Condition {
+	g := &conditionGroup{operator: operator}
+	fn(g)
+	return g.condition()
+}
+
+// Where adds conditions to the group
+func (g *conditionGroup) Where(conditions ...Condition) ConditionGroup {
+	for _, cond := range conditions {
+		if cond != nil {
+			g.conditions = append(g.conditions, cond)
+		}
+	}
+	return g
+}
+
+// Or adds the conditions joined with OR as a single member of the group
+func (g *conditionGroup) Or(conditions ...Condition) ConditionGroup {
+	return g.Where(buildGroup("OR", func(inner ConditionGroup) {
+		inner.Where(conditions...)
+	}))
+}
+
+// AndGroup adds a nested group whose conditions are joined with AND
+func (g *conditionGroup) AndGroup(fn func(g ConditionGroup)) ConditionGroup {
+	return g.Where(buildGroup("AND", fn))
+}
+
+// OrGroup adds a nested group whose conditions are joined with OR
+func (g *conditionGroup) OrGroup(fn func(g ConditionGroup)) ConditionGroup {
+	return g.Where(buildGroup("OR", fn))
+}
+
+// condition returns the collected conditions as one condition, or nil when
+// the group is empty so empty groups drop out of the query
+func (g *conditionGroup) condition() Condition {
+	switch len(g.conditions) {
+	case 0:
+		return nil
+	case 1:
+		return g.conditions[0]
+	default:
+		return &logicalCondition{operator: g.operator, conditions: g.conditions}
+	}
+}
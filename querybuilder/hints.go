@@ -0,0 +1,45 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// tableHints holds the index hints (MySQL) and table hints (SQL Server)
+// attached to a FROM or JOIN target
+type tableHints struct {
+	index []string // e.g. "USE INDEX (idx_a, idx_b)"
+	table []string // e.g. "NOLOCK"
+}
+
+func (h *tableHints) addIndexHint(kind string, indexes []string) {
+	h.index = append(h.index, kind+" INDEX ("+strings.Join(indexes, ", ")+")")
+}
+
+// validate checks that the hints are supported by the dialect
+func (h tableHints) validate(dialect Dialect) error {
+	if _, ok := dialect.(mysqlDialect); len(h.index) > 0 && !ok {
+		return errors.New("index hints are only supported by MySQL")
+	}
+	if _, ok := dialect.(sqlserverDialect); len(h.table) > 0 && !ok {
+		return errors.New("table hints are only supported by SQL Server")
+	}
+	return nil
+}
+
+// toSQL renders the hints to follow a table reference. Extra table hints,
+// such as those derived from row locking, are merged into the WITH list.
+func (h tableHints) toSQL(extra ...string) string {
+	var sql strings.Builder
+	for _, hint := range h.index {
+		sql.WriteString(" ")
+		sql.WriteString(hint)
+	}
+	hints := append(append([]string{}, h.table...), extra...)
+	if len(hints) > 0 {
+		sql.WriteString(" WITH (")
+		sql.WriteString(strings.Join(hints, ", "))
+		sql.WriteString(")")
+	}
+	return sql.String()
+}
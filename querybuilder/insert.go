@@ -1,9 +1,12 @@
 package querybuilder
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -12,18 +15,51 @@ type InsertBuilder interface {
 	Into(table string) InsertBuilder
 	Columns(columns ...string) InsertBuilder
 	Values(values ...any) InsertBuilder
+	ValuesRows(rows [][]any) InsertBuilder
+	SetMap(values map[string]any) InsertBuilder
 	FromSelect(selectBuilder SelectBuilder) InsertBuilder
+	With(alias string, builder SQLBuilder) InsertBuilder
+	WithRecursive(alias string, builder SQLBuilder) InsertBuilder
 	OnConflict(conflictAction ConflictAction) InsertBuilder
 	Returning(columns ...string) InsertBuilder
 	DefaultValues() InsertBuilder
 	ToSQL() (string, []any, error)
+	BuildBatches(maxParams int) ([]Query, error)
+	ExecReturningID(ctx context.Context, db Queryer) (int64, error)
+	Clone() InsertBuilder
+	Comment(text string) InsertBuilder
+	Prefix(sql string, args ...any) InsertBuilder
+	Suffix(sql string, args ...any) InsertBuilder
 }
 
-// ConflictAction defines what to do on conflict
+// ConflictAction defines what to do on conflict. On MySQL it is rendered
+// as ON DUPLICATE KEY UPDATE: DoNothing becomes a no-op update of Target,
+// or INSERT IGNORE when no Target is given.
+//
+// DoUpdate values are bound as parameters unless they are Excluded(col),
+// raw SQL, or a Fragment such as
+// NewFragment("people.hits + ?", Excluded("hits")), whose Excluded args are
+// rendered as references to the inserted row.
 type ConflictAction struct {
-	Target    string // column or constraint
-	DoNothing bool
-	DoUpdate  map[string]any
+	Target      string // column or constraint
+	Constraint  string // Postgres ON CONSTRAINT name, instead of Target
+	TargetWhere Condition
+	DoNothing   bool
+	DoUpdate    map[string]any
+	UpdateWhere Condition // only update rows matching this predicate
+	RowAlias    string    // MySQL 8.0.19+ alias for the inserted row, used instead of VALUES(col)
+}
+
+// excludedValue refers to the value proposed for insertion in an upsert
+type excludedValue struct {
+	column string
+}
+
+// Excluded refers to the value that would have been inserted into column,
+// for use in ConflictAction.DoUpdate. It renders EXCLUDED.col on Postgres
+// and SQLite, VALUES(col) or alias.col on MySQL, and source.col in a MERGE.
+func Excluded(column string) any {
+	return excludedValue{column: column}
 }
 
 // insertBuilder implements InsertBuilder
@@ -34,9 +70,15 @@ type insertBuilder struct {
 	values       [][]any
 	useDefaults  bool
 	fromSelect   SelectBuilder
+	ctes         []cte
 	conflict     *ConflictAction
 	returning    []string
 	paramCounter int
+	comments     []string
+	prefixes     []rawClause
+	suffixes     []rawClause
+	timestamps   *timestampColumns
+	err          error // first error raised while chaining, returned by ToSQL
 }
 
 // rawSQL is a helper type for embedding raw SQL expressions in value lists
@@ -90,32 +132,155 @@ func (ib *insertBuilder) Values(values ...any) InsertBuilder {
 	return ib
 }
 
+// ValuesRows adds several sets of values at once, e.g. for a batch insert.
+// Every row must have as many values as the columns, or as the first row
+// when no columns are given.
+func (ib *insertBuilder) ValuesRows(rows [][]any) InsertBuilder {
+	for _, row := range rows {
+		ib.Values(row...)
+	}
+	return ib
+}
+
+// SetMap adds a row from a column/value map. The first call sets the
+// columns in sorted order so the generated SQL is deterministic; later
+// calls must use the same keys.
+func (ib *insertBuilder) SetMap(values map[string]any) InsertBuilder {
+	if len(values) == 0 {
+		ib.setErr(errors.New("no values specified in SetMap"))
+		return ib
+	}
+	if len(ib.columns) == 0 {
+		ib.columns = slices.Sorted(maps.Keys(values))
+	}
+
+	row := make([]any, 0, len(ib.columns))
+	for _, col := range ib.columns {
+		val, ok := values[col]
+		if !ok {
+			ib.setErr(fmt.Errorf("SetMap is missing column %s", col))
+			return ib
+		}
+		row = append(row, val)
+	}
+	if len(values) != len(ib.columns) {
+		ib.setErr(fmt.Errorf("SetMap has %d values but %d columns", len(values), len(ib.columns)))
+		return ib
+	}
+	return ib.Values(row...)
+}
+
+// setErr records the first chaining error
+func (ib *insertBuilder) setErr(err error) {
+	if ib.err == nil {
+		ib.err = err
+	}
+}
+
 // FromSelect inserts data from a SELECT query
 func (ib *insertBuilder) FromSelect(selectBuilder SelectBuilder) InsertBuilder {
 	ib.fromSelect = selectBuilder
 	return ib
 }
 
+// With adds a common table expression to the statement, e.g. for
+// WITH ranked AS (...) INSERT INTO archive SELECT * FROM ranked. MySQL and
+// Oracle only allow it on INSERT ... SELECT, where it precedes the SELECT.
+func (ib *insertBuilder) With(alias string, builder SQLBuilder) InsertBuilder {
+	ib.ctes = append(ib.ctes, cte{alias: alias, builder: builder})
+	return ib
+}
+
+// WithRecursive adds a recursive common table expression to the statement
+func (ib *insertBuilder) WithRecursive(alias string, builder SQLBuilder) InsertBuilder {
+	ib.ctes = append(ib.ctes, cte{alias: alias, builder: builder, recursive: true})
+	return ib
+}
+
+// withBeforeSelect reports whether the WITH clause is written before the
+// SELECT of an INSERT ... SELECT rather than before the INSERT
+func (ib *insertBuilder) withBeforeSelect() bool {
+	switch ib.dialect.(type) {
+	case mysqlDialect, oracleDialect:
+		return true
+	}
+	return false
+}
+
 // OnConflict specifies conflict resolution
 func (ib *insertBuilder) OnConflict(conflictAction ConflictAction) InsertBuilder {
 	ib.conflict = &conflictAction
 	return ib
 }
 
-// Returning specifies columns to return after insert
+// Returning specifies columns to return after insert. It renders RETURNING
+// on PostgreSQL and SQLite and OUTPUT INSERTED.col on SQL Server; other
+// dialects return an error from ToSQL.
 func (ib *insertBuilder) Returning(columns ...string) InsertBuilder {
 	ib.returning = columns
 	return ib
 }
 
+// Comment prefixes the statement with a /* ... */ comment
+func (ib *insertBuilder) Comment(text string) InsertBuilder {
+	ib.comments = append(ib.comments, text)
+	return ib
+}
+
+// Prefix adds a raw SQL fragment with bound args before the statement
+func (ib *insertBuilder) Prefix(sql string, args ...any) InsertBuilder {
+	ib.prefixes = append(ib.prefixes, rawClause{sql: sql, args: args})
+	return ib
+}
+
+// Suffix adds a raw SQL fragment with bound args after the statement
+func (ib *insertBuilder) Suffix(sql string, args ...any) InsertBuilder {
+	ib.suffixes = append(ib.suffixes, rawClause{sql: sql, args: args})
+	return ib
+}
+
 // DefaultValues specifies to use DEFAULT VALUES clause
 func (ib *insertBuilder) DefaultValues() InsertBuilder {
 	ib.useDefaults = true
 	return ib
 }
 
-// ToSQL generates the SQL query and returns the query and parameters
+// Clone returns a deep copy of the builder that can be modified independently,
+// e.g. to add rows to a shared template insert from several goroutines
+func (ib *insertBuilder) Clone() InsertBuilder {
+	cloned := *ib
+	cloned.columns = slices.Clone(ib.columns)
+	cloned.values = slices.Clone(ib.values)
+	for i := range cloned.values {
+		cloned.values[i] = slices.Clone(cloned.values[i])
+	}
+	if ib.fromSelect != nil {
+		cloned.fromSelect = ib.fromSelect.Clone()
+	}
+	cloned.ctes = cloneCTEs(ib.ctes)
+	cloned.conflict = cloneConflict(ib.conflict)
+	cloned.returning = slices.Clone(ib.returning)
+	cloned.comments = slices.Clone(ib.comments)
+	cloned.prefixes = cloneRawClauses(ib.prefixes)
+	cloned.suffixes = cloneRawClauses(ib.suffixes)
+	return &cloned
+}
+
+// ToSQL generates the SQL query and returns the query and parameters. It
+// does not modify the builder, so a template may be rendered concurrently.
 func (ib *insertBuilder) ToSQL() (string, []any, error) {
+	b := *ib
+	return b.build()
+}
+
+// build renders the statement, numbering placeholders with paramCounter
+func (ib *insertBuilder) build() (string, []any, error) {
+	if ib.err != nil {
+		return "", nil, ib.err
+	}
+	if stamped := ib.withTimestamps(); stamped != nil {
+		return stamped.ToSQL()
+	}
 	if err := ib.validateInsert(); err != nil {
 		return "", nil, err
 	}
@@ -124,29 +289,79 @@ func (ib *insertBuilder) ToSQL() (string, []any, error) {
 		query strings.Builder
 		args  []any
 	)
+	ib.paramCounter = 0
 
-	query.WriteString("INSERT INTO ")
-	query.WriteString(ib.table)
+	prefixArgs := buildPrefixes(&query, ib.prefixes, ib.dialect, &ib.paramCounter)
+	args = append(args, prefixArgs...)
 
-	if err := ib.buildColumns(&query); err != nil {
-		return "", nil, err
+	buildComments(&query, ib.comments)
+
+	if !ib.withBeforeSelect() {
+		withArgs, err := buildWithClause(&query, ib.ctes, ib.dialect, &ib.paramCounter)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, withArgs...)
 	}
 
-	valArgs, err := ib.buildValuesOrSelectOrDefault(&query)
+	var (
+		stmtArgs []any
+		err      error
+	)
+	if ib.usesMerge() {
+		stmtArgs, err = ib.buildMerge(&query)
+	} else {
+		stmtArgs, err = ib.buildInsert(&query)
+	}
 	if err != nil {
 		return "", nil, err
 	}
+	args = append(args, stmtArgs...)
+
+	suffixArgs := buildSuffixes(&query, ib.suffixes, ib.dialect, &ib.paramCounter)
+	args = append(args, suffixArgs...)
+
+	// SQL Server requires MERGE statements to be terminated
+	if _, ok := ib.dialect.(sqlserverDialect); ok && ib.usesMerge() {
+		query.WriteString(";")
+	}
+
+	return query.String(), args, nil
+}
+
+// buildInsert writes the INSERT statement with its conflict and RETURNING clauses
+func (ib *insertBuilder) buildInsert(query *strings.Builder) ([]any, error) {
+	var args []any
+
+	if ib.insertIgnore() {
+		query.WriteString("INSERT IGNORE INTO ")
+	} else {
+		query.WriteString("INSERT INTO ")
+	}
+	query.WriteString(escapeTableRef(ib.dialect, ib.table))
+
+	if err := ib.buildColumns(query); err != nil {
+		return nil, err
+	}
+	if err := ib.validateReturning(); err != nil {
+		return nil, err
+	}
+	ib.buildOutput(query)
+
+	valArgs, err := ib.buildValuesOrSelectOrDefault(query)
+	if err != nil {
+		return nil, err
+	}
 	args = append(args, valArgs...)
 
-	conflictArgs, err := ib.buildOnConflict(&query)
+	conflictArgs, err := ib.buildOnConflict(query)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	args = append(args, conflictArgs...)
 
-	ib.buildReturning(&query)
-
-	return query.String(), args, nil
+	ib.buildReturning(query)
+	return args, nil
 }
 
 // validateInsert checks for correct insert configuration
@@ -181,6 +396,24 @@ func (ib *insertBuilder) validateInsert() error {
 			}
 		}
 	}
+	if len(ib.ctes) > 0 && ib.withBeforeSelect() && (ib.fromSelect == nil || ib.usesMerge()) {
+		return errors.New("WITH is only supported on INSERT ... SELECT for this dialect")
+	}
+	if ib.fromSelect != nil && len(ib.columns) > 0 {
+		if n, ok := projectedColumns(ib.fromSelect); ok && n != len(ib.columns) {
+			return fmt.Errorf("number of selected columns (%d) doesn't match columns (%d)",
+				n, len(ib.columns))
+		}
+	}
+	for i, valSet := range ib.values {
+		if len(valSet) == 0 {
+			return fmt.Errorf("row %d has no values", i+1)
+		}
+		if len(valSet) != len(ib.values[0]) {
+			return fmt.Errorf("number of values in row %d (%d) doesn't match the first row (%d)",
+				i+1, len(valSet), len(ib.values[0]))
+		}
+	}
 	return nil
 }
 
@@ -209,12 +442,20 @@ func (ib *insertBuilder) buildValuesOrSelectOrDefault(query *strings.Builder) ([
 
 	case ib.fromSelect != nil:
 		query.WriteString(" ")
+		if ib.withBeforeSelect() {
+			withArgs, err := buildWithClause(query, ib.ctes, ib.dialect, &ib.paramCounter)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, withArgs...)
+		}
 		selectSQL, selectArgs, err := ib.fromSelect.ToSQL()
 		if err != nil {
 			return nil, err
 		}
-		query.WriteString(selectSQL)
+		query.WriteString(shiftPlaceholders(selectSQL, ib.dialect, ib.paramCounter))
 		args = append(args, selectArgs...)
+		ib.paramCounter += len(selectArgs)
 
 	default:
 		query.WriteString(" VALUES ")
@@ -228,14 +469,11 @@ func (ib *insertBuilder) buildValuesOrSelectOrDefault(query *strings.Builder) ([
 					query.WriteString(", ")
 				}
 
-				// Handle rawSQL values
-				if raw, ok := val.(rawSQL); ok {
-					query.WriteString(raw.value)
-				} else {
-					query.WriteString(ib.dialect.Placeholder(ib.paramCounter))
-					args = append(args, val)
-					ib.paramCounter++
+				valArgs, err := writeValue(query, val, ib.dialect, &ib.paramCounter)
+				if err != nil {
+					return nil, err
 				}
+				args = append(args, valArgs...)
 			}
 			query.WriteString(")")
 		}
@@ -250,33 +488,190 @@ func (ib *insertBuilder) buildOnConflict(query *strings.Builder) ([]interface{},
 	if ib.conflict == nil {
 		return args, nil
 	}
+
+	if _, ok := ib.dialect.(mysqlDialect); ok {
+		return ib.buildOnDuplicateKey(query)
+	}
+
+	doUpdate := !ib.conflict.DoNothing && len(ib.conflict.DoUpdate) > 0
+	switch {
+	case ib.conflict.Constraint != "" && ib.conflict.Target != "":
+		return nil, errors.New("conflict target and constraint are mutually exclusive")
+	case ib.conflict.Constraint != "":
+		if _, ok := ib.dialect.(postgresDialect); !ok {
+			return nil, errors.New("ON CONFLICT ON CONSTRAINT is only supported by PostgreSQL")
+		}
+	case ib.conflict.Target == "" && (doUpdate || ib.conflict.TargetWhere != nil):
+		return nil, errors.New("ON CONFLICT DO UPDATE and WHERE require a conflict target")
+	}
+
 	query.WriteString(" ON CONFLICT")
-	if ib.conflict.Target != "" {
+	if ib.conflict.Constraint != "" {
+		query.WriteString(" ON CONSTRAINT " + ib.conflict.Constraint)
+	} else if ib.conflict.Target != "" {
 		query.WriteString(" (" + ib.conflict.Target + ")")
 	}
-	if ib.conflict.DoNothing {
+	if ib.conflict.TargetWhere != nil {
+		whereSQL, whereArgs, err := conditionToSQL(ib.conflict.TargetWhere, ib.dialect, &ib.paramCounter)
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(" WHERE " + whereSQL)
+		args = append(args, whereArgs...)
+	}
+
+	if !doUpdate {
 		query.WriteString(" DO NOTHING")
-	} else if len(ib.conflict.DoUpdate) > 0 {
-		query.WriteString(" DO UPDATE SET ")
-		first := true
-		for col, val := range ib.conflict.DoUpdate {
-			if !first {
-				query.WriteString(", ")
-			}
-			query.WriteString(col)
+		return args, nil
+	}
+	query.WriteString(" DO UPDATE SET ")
+	setArgs, err := ib.buildConflictAssignments(query)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, setArgs...)
+
+	if ib.conflict.UpdateWhere != nil {
+		whereSQL, whereArgs, err := conditionToSQL(ib.conflict.UpdateWhere, ib.dialect, &ib.paramCounter)
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(" WHERE " + whereSQL)
+		args = append(args, whereArgs...)
+	}
+	return args, nil
+}
+
+// buildOnDuplicateKey writes MySQL's ON DUPLICATE KEY UPDATE clause,
+// preceded by the row alias when one is set
+func (ib *insertBuilder) buildOnDuplicateKey(query *strings.Builder) ([]interface{}, error) {
+	if ib.conflict.Constraint != "" || ib.conflict.TargetWhere != nil || ib.conflict.UpdateWhere != nil {
+		return nil, errors.New("ON DUPLICATE KEY UPDATE does not support constraint or WHERE predicates")
+	}
+	if ib.conflict.RowAlias != "" {
+		if ib.fromSelect != nil || ib.useDefaults {
+			return nil, errors.New("row alias requires a VALUES insert")
+		}
+		query.WriteString(" AS ")
+		query.WriteString(ib.conflict.RowAlias)
+	}
+
+	switch {
+	case ib.conflict.DoNothing:
+		if ib.conflict.Target != "" {
+			query.WriteString(" ON DUPLICATE KEY UPDATE ")
+			query.WriteString(ib.conflict.Target)
 			query.WriteString(" = ")
-			query.WriteString(ib.dialect.Placeholder(ib.paramCounter))
-			args = append(args, val)
-			ib.paramCounter++
-			first = false
+			query.WriteString(ib.conflict.Target)
+		}
+		return nil, nil
+	case len(ib.conflict.DoUpdate) > 0:
+		query.WriteString(" ON DUPLICATE KEY UPDATE ")
+		return ib.buildConflictAssignments(query)
+	}
+	return nil, nil
+}
+
+// buildConflictAssignments writes the DoUpdate assignments sorted by column
+func (ib *insertBuilder) buildConflictAssignments(query *strings.Builder) ([]any, error) {
+	var args []any
+	for i, col := range slices.Sorted(maps.Keys(ib.conflict.DoUpdate)) {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(col)
+		query.WriteString(" = ")
+
+		switch val := ib.conflict.DoUpdate[col].(type) {
+		case excludedValue:
+			query.WriteString(ib.excludedRef(val.column))
+		case Fragment:
+			exprSQL, exprArgs, err := conditionToSQL(ib.bindExcluded(val), ib.dialect, &ib.paramCounter)
+			if err != nil {
+				return nil, err
+			}
+			query.WriteString(exprSQL)
+			args = append(args, exprArgs...)
+		default:
+			valArgs, err := writeValue(query, val, ib.dialect, &ib.paramCounter)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, valArgs...)
 		}
 	}
 	return args, nil
 }
 
+// bindExcluded replaces the Excluded args of a fragment with references to
+// the inserted row
+func (ib *insertBuilder) bindExcluded(f Fragment) Fragment {
+	args := slices.Clone(f.args)
+	for i, arg := range args {
+		if ex, ok := arg.(excludedValue); ok {
+			args[i] = NewFragment(ib.excludedRef(ex.column))
+		}
+	}
+	return NewFragment(f.sql, args...)
+}
+
+// excludedRef renders a reference to the value proposed for insertion
+func (ib *insertBuilder) excludedRef(column string) string {
+	switch ib.dialect.(type) {
+	case mysqlDialect:
+		if ib.conflict.RowAlias != "" {
+			return ib.conflict.RowAlias + "." + column
+		}
+		return "VALUES(" + column + ")"
+	case sqlserverDialect, oracleDialect:
+		return "source." + column
+	}
+	return "EXCLUDED." + column
+}
+
+// insertIgnore reports whether the MySQL statement is INSERT IGNORE, used
+// for DoNothing when there is no column to no-op update
+func (ib *insertBuilder) insertIgnore() bool {
+	_, isMySQL := ib.dialect.(mysqlDialect)
+	return isMySQL && ib.conflict != nil && ib.conflict.DoNothing && ib.conflict.Target == ""
+}
+
+// validateReturning checks that the dialect can return the inserted rows.
+// SQL Server uses OUTPUT instead of RETURNING.
+func (ib *insertBuilder) validateReturning() error {
+	if len(ib.returning) == 0 || supportsReturning(ib.dialect) {
+		return nil
+	}
+	switch ib.dialect.(type) {
+	case sqlserverDialect:
+		return nil
+	case mysqlDialect:
+		return errors.New("RETURNING is not supported by MySQL; read the generated key with LAST_INSERT_ID() or sql.Result.LastInsertId")
+	case oracleDialect:
+		return errors.New(`RETURNING is not supported by Oracle inserts; use Suffix("RETURNING id INTO ?", sql.Out{Dest: &id})`)
+	default:
+		return errors.New("RETURNING is not supported by this dialect")
+	}
+}
+
+// buildOutput writes SQL Server's OUTPUT clause, which goes before the
+// VALUES, SELECT or DEFAULT VALUES clause
+func (ib *insertBuilder) buildOutput(query *strings.Builder) {
+	if _, ok := ib.dialect.(sqlserverDialect); !ok || len(ib.returning) == 0 {
+		return
+	}
+	query.WriteString(" OUTPUT ")
+	for i, col := range ib.returning {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("INSERTED." + col)
+	}
+}
+
 // buildReturning writes the RETURNING clause if needed
 func (ib *insertBuilder) buildReturning(query *strings.Builder) {
-	if len(ib.returning) > 0 {
+	if len(ib.returning) > 0 && supportsReturning(ib.dialect) {
 		query.WriteString(" RETURNING ")
 		for i, col := range ib.returning {
 			if i > 0 {
@@ -291,21 +686,12 @@ func (ib *insertBuilder) CurrentTimestamp() any {
 	return Raw("CURRENT_TIMESTAMP")
 }
 
+// Func builds a function call value such as Func("LOWER", email), binding
+// its args; raw SQL args are written inline.
 func (ib *insertBuilder) Func(funcName string, args ...any) any {
-	var parts []string
-	var placeholders []string
-
-	for _, arg := range args {
-		if raw, ok := arg.(rawSQL); ok {
-			parts = append(parts, raw.value)
-		} else {
-			placeholders = append(placeholders, ib.dialect.Placeholder(ib.paramCounter))
-			ib.paramCounter++
-		}
-	}
-
-	if len(placeholders) > 0 {
-		return Raw(fmt.Sprintf("%s(%s)", funcName, strings.Join(placeholders, ",")))
+	placeholders := make([]string, len(args))
+	for i := range args {
+		placeholders[i] = "?"
 	}
-	return Raw(fmt.Sprintf("%s(%s)", funcName, strings.Join(parts, ",")))
+	return ExprValue(fmt.Sprintf("%s(%s)", funcName, strings.Join(placeholders, ", ")), args...)
 }
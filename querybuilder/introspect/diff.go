@@ -0,0 +1,251 @@
+package introspect
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// Diff returns the statements that bring the current schema, as read by
+// Inspect, to the target schema. The target is declared with the same
+// types:
+//
+//	target := []introspect.Table{{
+//		Name:       "users",
+//		Columns:    []introspect.Column{{Name: "id", Type: "bigint"}, {Name: "email", Type: "text"}},
+//		PrimaryKey: []string{"id"},
+//		Indexes:    []introspect.Index{{Name: "users_email_key", Columns: []string{"email"}, Unique: true}},
+//	}}
+//
+// Missing tables are created; columns, indexes and foreign keys are added,
+// changed or dropped to match. Tables absent from the target are left
+// alone, and primary keys of existing tables are not changed. Column types
+// are compared ignoring case, so declare them as the database reports them;
+// defaults only apply to new columns. Foreign keys of existing SQLite tables
+// are unnamed and not compared.
+//
+// Each change is its own statement so the result runs on every dialect.
+func Diff(dialect querybuilder.Dialect, current, target []Table) []querybuilder.SQLBuilder {
+	d := differ{qb: querybuilder.New().WithDialect(dialect), sqlite: querybuilder.DialectName(dialect) == "sqlite"}
+
+	// Foreign keys go first so that the columns and indexes they use can be
+	// dropped, and are added last so that the tables they reference exist.
+	var pairs [][2]*Table
+	for i := range target {
+		pairs = append(pairs, [2]*Table{findTable(current, target[i].Name), &target[i]})
+	}
+	for _, p := range pairs {
+		if p[0] != nil {
+			d.dropForeignKeys(p[0], p[1])
+		}
+	}
+	for _, p := range pairs {
+		if p[0] == nil {
+			d.createTable(p[1])
+		} else {
+			d.alterTable(p[0], p[1])
+		}
+	}
+	for _, p := range pairs {
+		d.addForeignKeys(p[0], p[1])
+	}
+	return d.statements
+}
+
+// Plan inspects the database and renders the statements that bring it to
+// the target schema, without running them
+func (in *Inspector) Plan(ctx context.Context, target []Table) ([]string, error) {
+	current, err := in.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, stmt := range Diff(in.dialect, current, target) {
+		query, _, err := stmt.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// Sync brings the database to the target schema and returns the statements
+// it ran. It is meant for development databases; use migrations elsewhere.
+func (in *Inspector) Sync(ctx context.Context, target []Table) ([]string, error) {
+	queries, err := in.Plan(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	for i, query := range queries {
+		if _, err := in.db.ExecContext(ctx, query); err != nil {
+			return queries[:i], err
+		}
+	}
+	return queries, nil
+}
+
+// differ collects the statements of a Diff
+type differ struct {
+	qb         querybuilder.Builder
+	sqlite     bool
+	statements []querybuilder.SQLBuilder
+}
+
+func (d *differ) add(stmt querybuilder.SQLBuilder) {
+	d.statements = append(d.statements, stmt)
+}
+
+// createTable creates a missing table with its indexes. SQLite cannot add
+// foreign keys later, so they are declared inline there.
+func (d *differ) createTable(table *Table) {
+	cb := d.qb.CreateTable(table.Name)
+	for _, col := range table.Columns {
+		cb.Column(col.Name, columnDefinition(col))
+	}
+	if len(table.PrimaryKey) > 0 {
+		cb.PrimaryKey(table.PrimaryKey...)
+	}
+	if d.sqlite {
+		for _, fk := range table.ForeignKeys {
+			cb.Constraint(fk.Name, foreignKey(fk))
+		}
+	}
+	d.add(cb)
+	for _, idx := range table.Indexes {
+		d.add(d.createIndex(table.Name, idx))
+	}
+}
+
+// alterTable converges the columns and indexes of an existing table
+func (d *differ) alterTable(current, target *Table) {
+	for _, idx := range current.Indexes {
+		if want := findIndex(target.Indexes, idx.Name); want == nil || !sameIndex(idx, *want) {
+			d.add(d.qb.DropIndex(idx.Name, current.Name))
+		}
+	}
+	for _, col := range target.Columns {
+		have := findColumn(current.Columns, col.Name)
+		switch {
+		case have == nil:
+			d.add(d.qb.AlterTable(current.Name).AddColumn(col.Name, columnDefinition(col)))
+		case !strings.EqualFold(strings.TrimSpace(have.Type), strings.TrimSpace(col.Type)) || have.Nullable != col.Nullable:
+			d.add(d.qb.AlterTable(current.Name).AlterColumn(col.Name, col.Type, col.Nullable))
+		}
+	}
+	for _, col := range current.Columns {
+		if findColumn(target.Columns, col.Name) == nil {
+			d.add(d.qb.AlterTable(current.Name).DropColumn(col.Name))
+		}
+	}
+	for _, idx := range target.Indexes {
+		if have := findIndex(current.Indexes, idx.Name); have == nil || !sameIndex(*have, idx) {
+			d.add(d.createIndex(current.Name, idx))
+		}
+	}
+}
+
+// dropForeignKeys drops the foreign keys of an existing table that the
+// target removes or changes
+func (d *differ) dropForeignKeys(current, target *Table) {
+	if d.sqlite {
+		return
+	}
+	for _, fk := range current.ForeignKeys {
+		if want := findForeignKey(target.ForeignKeys, fk.Name); want == nil || !sameForeignKey(fk, *want) {
+			d.add(d.qb.AlterTable(current.Name).DropConstraint(fk.Name))
+		}
+	}
+}
+
+// addForeignKeys adds the foreign keys that current lacks or that were
+// dropped because they changed; current is nil for a new table
+func (d *differ) addForeignKeys(current, target *Table) {
+	if d.sqlite {
+		return
+	}
+	table := target.Name
+	if current != nil {
+		table = current.Name
+	}
+	for _, fk := range target.ForeignKeys {
+		if current != nil {
+			if have := findForeignKey(current.ForeignKeys, fk.Name); have != nil && sameForeignKey(*have, fk) {
+				continue
+			}
+		}
+		d.add(d.qb.AlterTable(table).AddConstraint(fk.Name, foreignKey(fk)))
+	}
+}
+
+func (d *differ) createIndex(table string, idx Index) querybuilder.SQLBuilder {
+	cb := d.qb.CreateIndex(idx.Name).On(table, idx.Columns...)
+	if idx.Unique {
+		cb.Unique()
+	}
+	return cb
+}
+
+// columnDefinition renders the type, default and nullability of a column
+func columnDefinition(col Column) string {
+	def := col.Type
+	if col.Default != nil {
+		def += " DEFAULT " + *col.Default
+	}
+	if !col.Nullable {
+		def += " NOT NULL"
+	}
+	return def
+}
+
+func foreignKey(fk ForeignKey) querybuilder.Constraint {
+	return querybuilder.ForeignKey(fk.Columns...).References(fk.RefTable, fk.RefColumns...)
+}
+
+func sameIndex(a, b Index) bool {
+	return a.Unique == b.Unique && slices.EqualFunc(a.Columns, b.Columns, strings.EqualFold)
+}
+
+func sameForeignKey(a, b ForeignKey) bool {
+	return strings.EqualFold(a.RefTable, b.RefTable) &&
+		slices.EqualFunc(a.Columns, b.Columns, strings.EqualFold) &&
+		slices.EqualFunc(a.RefColumns, b.RefColumns, strings.EqualFold)
+}
+
+func findTable(tables []Table, name string) *Table {
+	for i := range tables {
+		if strings.EqualFold(tables[i].Name, name) {
+			return &tables[i]
+		}
+	}
+	return nil
+}
+
+func findColumn(columns []Column, name string) *Column {
+	for i := range columns {
+		if strings.EqualFold(columns[i].Name, name) {
+			return &columns[i]
+		}
+	}
+	return nil
+}
+
+func findIndex(indexes []Index, name string) *Index {
+	for i := range indexes {
+		if strings.EqualFold(indexes[i].Name, name) {
+			return &indexes[i]
+		}
+	}
+	return nil
+}
+
+func findForeignKey(fks []ForeignKey, name string) *ForeignKey {
+	for i := range fks {
+		if strings.EqualFold(fks[i].Name, name) {
+			return &fks[i]
+		}
+	}
+	return nil
+}
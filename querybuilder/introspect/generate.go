@@ -0,0 +1,162 @@
+package introspect
+
+import (
+	"context"
+	"errors"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Generate renders Go source for package pkg with a struct per table and
+// constants naming the table and its columns, so builders can refer to
+// generated identifiers instead of string literals:
+//
+//	// Orders is a row of the orders table
+//	type Orders struct {
+//		ID         int64   `db:"id"`
+//		CustomerID int64   `db:"customer_id"`
+//		Note       *string `db:"note"`
+//	}
+//
+//	const (
+//		OrdersTable      = "orders"
+//		OrdersID         = "id"
+//		OrdersCustomerID = "customer_id"
+//		OrdersNote       = "note"
+//	)
+//
+//	var OrdersColumns = []string{OrdersID, OrdersCustomerID, OrdersNote}
+//
+// The structs scan with ExecReturning and feed SetStruct. Nullable columns
+// become pointers, and types without a Go counterpart become any.
+func Generate(pkg string, tables []Table) ([]byte, error) {
+	if pkg == "" {
+		return nil, errors.New("no package name specified")
+	}
+
+	var body strings.Builder
+	imports := map[string]bool{}
+	for _, table := range tables {
+		if len(table.Columns) == 0 {
+			return nil, errors.New("table " + table.Name + " has no columns")
+		}
+		typeName := goName(table.Name)
+		declared := map[string]bool{typeName + "Table": true, typeName + "Columns": true}
+		fields := map[string]bool{}
+
+		body.WriteString("\n// " + typeName + " is a row of the " + table.Name + " table\n")
+		body.WriteString("type " + typeName + " struct {\n")
+		for _, col := range table.Columns {
+			field := goName(col.Name)
+			if fields[field] || declared[typeName+field] {
+				return nil, errors.New("column " + col.Name + " of table " + table.Name + " maps to a Go name already in use")
+			}
+			fields[field] = true
+			goType := goType(col)
+			if strings.HasPrefix(strings.TrimPrefix(goType, "*"), "time.") {
+				imports["time"] = true
+			}
+			body.WriteString("\t" + field + " " + goType + " `db:\"" + col.Name + "\"`\n")
+		}
+		body.WriteString("}\n")
+
+		body.WriteString("\n// Table and column names of " + table.Name + "\n")
+		body.WriteString("const (\n")
+		body.WriteString("\t" + typeName + "Table = " + strconv.Quote(table.Name) + "\n")
+		var names []string
+		for _, col := range table.Columns {
+			name := typeName + goName(col.Name)
+			names = append(names, name)
+			body.WriteString("\t" + name + " = " + strconv.Quote(col.Name) + "\n")
+		}
+		body.WriteString(")\n")
+
+		body.WriteString("\n// " + typeName + "Columns lists the columns of " + table.Name + " in table order\n")
+		body.WriteString("var " + typeName + "Columns = []string{" + strings.Join(names, ", ") + "}\n")
+	}
+
+	var src strings.Builder
+	src.WriteString("// Code generated by introspect. DO NOT EDIT.\n\n")
+	src.WriteString("package " + pkg + "\n")
+	if imports["time"] {
+		src.WriteString("\nimport \"time\"\n")
+	}
+	src.WriteString(body.String())
+	return format.Source([]byte(src.String()))
+}
+
+// Generate inspects the database and renders its tables with Generate
+func (in *Inspector) Generate(ctx context.Context, pkg string) ([]byte, error) {
+	tables, err := in.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Generate(pkg, tables)
+}
+
+// goType maps the SQL type of a column to a Go type
+func goType(col Column) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(col.Type)), "(")
+	base = strings.TrimSpace(strings.TrimSuffix(base, " unsigned"))
+
+	var t string
+	switch {
+	case base == "bytea" || base == "raw" || base == "image" ||
+		strings.HasSuffix(base, "blob") || strings.HasSuffix(base, "binary"):
+		return "[]byte"
+	case base == "boolean" || base == "bool" || base == "bit":
+		t = "bool"
+	case base == "int" || base == "integer" || base == "int2" || base == "int4" || base == "int8" ||
+		base == "tinyint" || base == "smallint" || base == "mediumint" || base == "bigint" ||
+		strings.HasSuffix(base, "serial"):
+		t = "int64"
+	case base == "real" || base == "float" || strings.HasPrefix(base, "double") ||
+		base == "float4" || base == "float8" || base == "numeric" || base == "decimal" ||
+		base == "number" || base == "money" || strings.HasPrefix(base, "binary_"):
+		t = "float64"
+	case strings.HasPrefix(base, "timestamp") || strings.HasPrefix(base, "datetime") ||
+		base == "date" || base == "time" || strings.HasPrefix(base, "time ") ||
+		base == "smalldatetime":
+		t = "time.Time"
+	case strings.Contains(base, "char") || strings.HasSuffix(base, "text") ||
+		strings.HasSuffix(base, "clob") || base == "uuid" || base == "uniqueidentifier" ||
+		base == "json" || base == "jsonb" || base == "xml" || base == "enum" || base == "citext":
+		t = "string"
+	default:
+		return "any"
+	}
+	if col.Nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// initialisms are name parts written in upper case, as golint expects
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// goName turns a table or column name such as "customer_id" into an
+// exported Go identifier such as "CustomerID"
+func goName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var ident strings.Builder
+	for _, part := range parts {
+		if initialisms[strings.ToLower(part)] {
+			ident.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		ident.WriteRune(unicode.ToUpper(runes[0]))
+		ident.WriteString(string(runes[1:]))
+	}
+	if ident.Len() == 0 || unicode.IsDigit([]rune(ident.String())[0]) {
+		return "X" + ident.String()
+	}
+	return ident.String()
+}
@@ -0,0 +1,203 @@
+// Package introspect reads the structure of a live database — tables,
+// columns, indexes and foreign keys — from information_schema or the
+// dialect's own catalog, with queries built by the query builder:
+//
+//	in := introspect.New(db, querybuilder.NewPostgreSQLDialect())
+//	tables, err := in.Inspect(ctx)
+//
+// Diff compares an inspected schema with a declared one and returns the
+// DDL statements that converge them; Inspector.Sync runs them.
+// Generate renders Go structs and column constants for the tables.
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// Table is an introspected table
+type Table struct {
+	Name        string
+	Columns     []Column
+	PrimaryKey  []string
+	Indexes     []Index
+	ForeignKeys []ForeignKey
+}
+
+// Column is a table column
+type Column struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	Default    *string // nil when the column has no default
+	PrimaryKey bool
+}
+
+// Index is a secondary index; the primary key's index is not listed
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// ForeignKey is a foreign key constraint. SQLite foreign keys are unnamed.
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// Inspector reads the schema of a database
+type Inspector struct {
+	db      querybuilder.Queryer
+	dialect querybuilder.Dialect
+	schema  string
+}
+
+// New creates an inspector for db, which may be a *sql.DB, *sql.Tx or
+// *sql.Conn, using the dialect of its driver
+func New(db querybuilder.Queryer, dialect querybuilder.Dialect) *Inspector {
+	return &Inspector{db: db, dialect: dialect}
+}
+
+// Schema restricts the inspector to a schema. It defaults to the current
+// schema (database on MySQL); SQLite and Oracle always read the current one.
+func (in *Inspector) Schema(schema string) *Inspector {
+	in.schema = schema
+	return in
+}
+
+// builder returns a query builder bound to the inspector's dialect
+func (in *Inspector) builder() querybuilder.Builder {
+	return querybuilder.New().WithDialect(in.dialect)
+}
+
+// Inspect reads every table of the schema
+func (in *Inspector) Inspect(ctx context.Context) ([]Table, error) {
+	names, err := in.Tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]Table, 0, len(names))
+	for _, name := range names {
+		table, err := in.Table(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, *table)
+	}
+	return tables, nil
+}
+
+// Tables lists the names of the base tables of the schema, sorted
+func (in *Inspector) Tables(ctx context.Context) ([]string, error) {
+	var sb querybuilder.SelectBuilder
+	switch querybuilder.DialectName(in.dialect) {
+	case "sqlite":
+		sb = in.builder().Select("name").From("sqlite_master").
+			Where(querybuilder.Eq("type", "table"), querybuilder.NotLike("name", "sqlite_%")).
+			OrderBy("name", "ASC")
+	case "oracle":
+		sb = in.builder().Select("table_name").From("user_tables").OrderBy("table_name", "ASC")
+	case "":
+		return nil, errors.New("introspection requires a built-in dialect")
+	default:
+		sb = in.builder().Select("table_name").From("information_schema.tables").
+			Where(in.schemaCondition("table_schema"), querybuilder.Eq("table_type", "BASE TABLE")).
+			OrderBy("table_name", "ASC")
+	}
+
+	var names []string
+	err := in.query(ctx, sb, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	return names, err
+}
+
+// Table reads one table
+func (in *Inspector) Table(ctx context.Context, name string) (*Table, error) {
+	table := &Table{Name: name}
+	var err error
+	if table.Columns, err = in.columns(ctx, name); err != nil {
+		return nil, err
+	}
+	if len(table.Columns) == 0 {
+		return nil, errors.New("table " + name + " not found")
+	}
+	if table.PrimaryKey, err = in.primaryKey(ctx, name); err != nil {
+		return nil, err
+	}
+	for i, col := range table.Columns {
+		table.Columns[i].PrimaryKey = containsFold(table.PrimaryKey, col.Name)
+	}
+	if table.Indexes, err = in.indexes(ctx, name); err != nil {
+		return nil, err
+	}
+	if table.ForeignKeys, err = in.foreignKeys(ctx, name); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// schemaCondition matches column against the inspected schema, or the
+// current one when none is set
+func (in *Inspector) schemaCondition(column string) querybuilder.Condition {
+	if in.schema != "" {
+		return querybuilder.Eq(column, in.schema)
+	}
+	switch querybuilder.DialectName(in.dialect) {
+	case "mysql":
+		return querybuilder.Expr(column + " = DATABASE()")
+	case "sqlserver":
+		return querybuilder.Expr(column + " = SCHEMA_NAME()")
+	default:
+		return querybuilder.Expr(column + " = current_schema()")
+	}
+}
+
+// objectName returns the table name as SQL Server's OBJECT_ID expects it
+func (in *Inspector) objectName(table string) string {
+	if in.schema == "" {
+		return table
+	}
+	return in.schema + "." + table
+}
+
+// query runs the select and calls scan for every row
+func (in *Inspector) query(ctx context.Context, sb querybuilder.SelectBuilder, scan func(rows *sql.Rows) error) error {
+	query, args, err := sb.ToSQL()
+	if err != nil {
+		return err
+	}
+	rows, err := in.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// containsFold reports whether name is in names, ignoring case
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// fakeCatalog answers each query with the rows of the first answer whose
+// key it contains, and with no rows otherwise
+type fakeCatalog struct {
+	answers []answer
+	queries []string
+}
+
+type answer struct {
+	key  string
+	rows [][]driver.Value
+}
+
+func (f *fakeCatalog) Connect(context.Context) (driver.Conn, error) { return fakeConn{f}, nil }
+func (f *fakeCatalog) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ catalog *fakeCatalog }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error)      { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                             { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                { return nil, errors.New("not supported") }
+func (c fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.catalog.queries = append(c.catalog.queries, query)
+	for _, a := range c.catalog.answers {
+		if strings.Contains(query, a.key) {
+			return &fakeRows{values: a.rows}, nil
+		}
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct{ values [][]driver.Value }
+
+func (r *fakeRows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	return make([]string, len(r.values[0]))
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestInspectPostgres(t *testing.T) {
+	catalog := &fakeCatalog{answers: []answer{
+		{`"information_schema"."tables"`, [][]driver.Value{{"orders"}}},
+		{`"information_schema"."columns"`, [][]driver.Value{
+			{"id", "bigint", "NO", nil},
+			{"customer_id", "bigint", "NO", nil},
+			{"note", "text", "YES", "''::text"},
+		}},
+		{`"information_schema"."table_constraints"`, [][]driver.Value{{"id"}}},
+		{"pg_index", [][]driver.Value{
+			{"orders_customer_idx", false, "customer_id"},
+			{"orders_customer_note_key", true, "customer_id"},
+			{"orders_customer_note_key", true, "note"},
+		}},
+		{`"information_schema"."referential_constraints"`, [][]driver.Value{{"orders_customer_fk", "customer_id", "customers", "id"}}},
+	}}
+	db := sql.OpenDB(catalog)
+	defer db.Close()
+
+	tables, err := New(db, querybuilder.NewPostgreSQLDialect()).Inspect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	note := "''::text"
+	expected := []Table{{
+		Name: "orders",
+		Columns: []Column{
+			{Name: "id", Type: "bigint", PrimaryKey: true},
+			{Name: "customer_id", Type: "bigint"},
+			{Name: "note", Type: "text", Nullable: true, Default: &note},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []Index{
+			{Name: "orders_customer_idx", Columns: []string{"customer_id"}},
+			{Name: "orders_customer_note_key", Columns: []string{"customer_id", "note"}, Unique: true},
+		},
+		ForeignKeys: []ForeignKey{{Name: "orders_customer_fk", Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}}},
+	}}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected %+v, got %+v", expected, tables)
+	}
+	for _, q := range catalog.queries {
+		t.Logf("query ===> %s", q)
+	}
+}
+
+func TestInspectSQLite(t *testing.T) {
+	catalog := &fakeCatalog{answers: []answer{
+		{"WHERE pk > ?", [][]driver.Value{{"id"}}},
+		{"pragma_table_info", [][]driver.Value{{"id", "INTEGER", "NO", nil}, {"email", "TEXT", "NO", nil}}},
+		{"pragma_index_list", [][]driver.Value{{"people_email_key", true}}},
+		{"pragma_index_info", [][]driver.Value{{"email"}}},
+		{"pragma_foreign_key_list", [][]driver.Value{{int64(0), "team_id", "teams", "id"}}},
+	}}
+	db := sql.OpenDB(catalog)
+	defer db.Close()
+
+	table, err := New(db, querybuilder.NewSQLiteDialect()).Table(context.Background(), "people")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(table.PrimaryKey, []string{"id"}) ||
+		!reflect.DeepEqual(table.Indexes, []Index{{Name: "people_email_key", Columns: []string{"email"}, Unique: true}}) ||
+		len(table.ForeignKeys) != 1 || table.ForeignKeys[0].Name != "" || table.ForeignKeys[0].RefTable != "teams" {
+		t.Errorf("unexpected table %+v", table)
+	}
+}
+
+func TestInspectMissingTable(t *testing.T) {
+	db := sql.OpenDB(&fakeCatalog{})
+	defer db.Close()
+
+	for _, dialect := range []querybuilder.Dialect{
+		querybuilder.NewMySQLDialect(), querybuilder.NewSQLServerDialect(), querybuilder.NewOracleDialect(),
+	} {
+		if _, err := New(db, dialect).Table(context.Background(), "missing"); err == nil {
+			t.Errorf("%s: should return error", querybuilder.DialectName(dialect))
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	empty := "''"
+	current := []Table{{
+		Name: "orders",
+		Columns: []Column{
+			{Name: "id", Type: "bigint", PrimaryKey: true},
+			{Name: "customer_id", Type: "bigint"},
+			{Name: "legacy_code", Type: "text", Nullable: true},
+			{Name: "note", Type: "varchar(50)", Nullable: true},
+		},
+		PrimaryKey:  []string{"id"},
+		Indexes:     []Index{{Name: "orders_customer_idx", Columns: []string{"customer_id"}}},
+		ForeignKeys: []ForeignKey{{Name: "orders_customer_fk", Columns: []string{"customer_id"}, RefTable: "clients", RefColumns: []string{"id"}}},
+	}}
+	target := []Table{
+		{
+			Name:       "customers",
+			Columns:    []Column{{Name: "id", Type: "bigint"}, {Name: "name", Type: "text", Default: &empty}},
+			PrimaryKey: []string{"id"},
+			Indexes:    []Index{{Name: "customers_name_key", Columns: []string{"name"}, Unique: true}},
+		},
+		{
+			Name: "ORDERS",
+			Columns: []Column{
+				{Name: "id", Type: "BIGINT"},
+				{Name: "customer_id", Type: "bigint"},
+				{Name: "note", Type: "text", Nullable: true},
+				{Name: "status", Type: "text", Default: &empty},
+			},
+			PrimaryKey:  []string{"id"},
+			Indexes:     []Index{{Name: "orders_customer_idx", Columns: []string{"customer_id", "status"}}},
+			ForeignKeys: []ForeignKey{{Name: "orders_customer_fk", Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}}},
+		},
+	}
+
+	var queries []string
+	for _, stmt := range Diff(querybuilder.NewPostgreSQLDialect(), current, target) {
+		query, _, err := stmt.ToSQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		queries = append(queries, query)
+	}
+	expected := []string{
+		`ALTER TABLE "orders" DROP CONSTRAINT orders_customer_fk`,
+		`CREATE TABLE "customers" (id bigint NOT NULL, name text DEFAULT '' NOT NULL, PRIMARY KEY (id))`,
+		`CREATE UNIQUE INDEX customers_name_key ON "customers" (name)`,
+		"DROP INDEX orders_customer_idx",
+		`ALTER TABLE "orders" ALTER COLUMN note TYPE text, ALTER COLUMN note DROP NOT NULL`,
+		`ALTER TABLE "orders" ADD COLUMN status text DEFAULT '' NOT NULL`,
+		`ALTER TABLE "orders" DROP COLUMN legacy_code`,
+		`CREATE INDEX orders_customer_idx ON "orders" (customer_id, status)`,
+		`ALTER TABLE "orders" ADD CONSTRAINT orders_customer_fk FOREIGN KEY (customer_id) REFERENCES "customers" (id)`,
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected %q, got %q", expected, queries)
+	}
+
+	if stmts := Diff(querybuilder.NewPostgreSQLDialect(), target, target); len(stmts) != 0 {
+		t.Errorf("expected no changes, got %d statements", len(stmts))
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate("models", []Table{{
+		Name: "order_items",
+		Columns: []Column{
+			{Name: "id", Type: "bigint", PrimaryKey: true},
+			{Name: "order_id", Type: "integer"},
+			{Name: "unit_price", Type: "numeric(10,2)"},
+			{Name: "note", Type: "character varying", Nullable: true},
+			{Name: "shipped_at", Type: "timestamp with time zone", Nullable: true},
+			{Name: "attrs", Type: "hstore"},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "// Code generated by introspect. DO NOT EDIT.\n\n" +
+		"package models\n\n" +
+		"import \"time\"\n\n" +
+		"// OrderItems is a row of the order_items table\n" +
+		"type OrderItems struct {\n" +
+		"\tID        int64      `db:\"id\"`\n" +
+		"\tOrderID   int64      `db:\"order_id\"`\n" +
+		"\tUnitPrice float64    `db:\"unit_price\"`\n" +
+		"\tNote      *string    `db:\"note\"`\n" +
+		"\tShippedAt *time.Time `db:\"shipped_at\"`\n" +
+		"\tAttrs     any        `db:\"attrs\"`\n" +
+		"}\n\n" +
+		"// Table and column names of order_items\n" +
+		"const (\n" +
+		"\tOrderItemsTable     = \"order_items\"\n" +
+		"\tOrderItemsID        = \"id\"\n" +
+		"\tOrderItemsOrderID   = \"order_id\"\n" +
+		"\tOrderItemsUnitPrice = \"unit_price\"\n" +
+		"\tOrderItemsNote      = \"note\"\n" +
+		"\tOrderItemsShippedAt = \"shipped_at\"\n" +
+		"\tOrderItemsAttrs     = \"attrs\"\n" +
+		")\n\n" +
+		"// OrderItemsColumns lists the columns of order_items in table order\n" +
+		"var OrderItemsColumns = []string{OrderItemsID, OrderItemsOrderID, OrderItemsUnitPrice, OrderItemsNote, OrderItemsShippedAt, OrderItemsAttrs}\n"
+	if string(src) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, src)
+	}
+
+	if _, err := Generate("models", []Table{{Name: "users", Columns: []Column{{Name: "user_id"}, {Name: "userID"}}}}); err == nil {
+		t.Error("should return error for clashing names")
+	}
+}
@@ -0,0 +1,236 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// columns reads the columns of a table in ordinal order
+func (in *Inspector) columns(ctx context.Context, table string) ([]Column, error) {
+	var sb querybuilder.SelectBuilder
+	switch querybuilder.DialectName(in.dialect) {
+	case "sqlite":
+		sb = in.builder().Select("name", "type", `CASE WHEN "notnull" = 0 THEN 'YES' ELSE 'NO' END`, "dflt_value").
+			FromFunction("pragma_table_info", table).OrderBy("cid", "ASC")
+	case "oracle":
+		sb = in.builder().Select("column_name", "data_type", "CASE nullable WHEN 'Y' THEN 'YES' ELSE 'NO' END", "data_default").
+			From("user_tab_columns").Where(querybuilder.Eq("table_name", table)).OrderBy("column_id", "ASC")
+	default:
+		dataType := "data_type"
+		if querybuilder.DialectName(in.dialect) == "mysql" {
+			dataType = "column_type" // keeps the length, e.g. varchar(255)
+		}
+		sb = in.builder().Select("column_name", dataType, "is_nullable", "column_default").
+			From("information_schema.columns").
+			Where(in.schemaCondition("table_schema"), querybuilder.Eq("table_name", table)).
+			OrderBy("ordinal_position", "ASC")
+	}
+
+	var columns []Column
+	err := in.query(ctx, sb, func(rows *sql.Rows) error {
+		var (
+			col        Column
+			isNullable string
+			def        sql.NullString
+		)
+		if err := rows.Scan(&col.Name, &col.Type, &isNullable, &def); err != nil {
+			return err
+		}
+		col.Nullable = isNullable == "YES"
+		if def.Valid {
+			col.Default = &def.String
+		}
+		columns = append(columns, col)
+		return nil
+	})
+	return columns, err
+}
+
+// primaryKey reads the primary key columns of a table in key order
+func (in *Inspector) primaryKey(ctx context.Context, table string) ([]string, error) {
+	var sb querybuilder.SelectBuilder
+	switch querybuilder.DialectName(in.dialect) {
+	case "sqlite":
+		sb = in.builder().Select("name").FromFunction("pragma_table_info", table).
+			Where(querybuilder.Gt("pk", 0)).OrderBy("pk", "ASC")
+	case "oracle":
+		sb = in.builder().Select("cc.column_name").From("user_constraints c").
+			Join("user_cons_columns cc", "cc.constraint_name = c.constraint_name").
+			Where(querybuilder.Eq("c.constraint_type", "P"), querybuilder.Eq("c.table_name", table)).
+			OrderBy("cc.position", "ASC")
+	default:
+		sb = in.builder().Select("kcu.column_name").From("information_schema.table_constraints tc").
+			Join("information_schema.key_column_usage kcu",
+				"kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name").
+			Where(querybuilder.Eq("tc.constraint_type", "PRIMARY KEY"), in.schemaCondition("tc.table_schema"),
+				querybuilder.Eq("tc.table_name", table)).
+			OrderBy("kcu.ordinal_position", "ASC")
+	}
+
+	var columns []string
+	err := in.query(ctx, sb, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		columns = append(columns, name)
+		return nil
+	})
+	return columns, err
+}
+
+// indexes reads the secondary indexes of a table. Expression key parts are
+// left out.
+func (in *Inspector) indexes(ctx context.Context, table string) ([]Index, error) {
+	var sb querybuilder.SelectBuilder
+	switch querybuilder.DialectName(in.dialect) {
+	case "sqlite":
+		return in.sqliteIndexes(ctx, table)
+	case "postgres":
+		sb = in.builder().Select("i.relname", "ix.indisunique", "a.attname").From("pg_index ix").
+			Join("pg_class t", "t.oid = ix.indrelid").
+			Join("pg_class i", "i.oid = ix.indexrelid").
+			Join("pg_namespace n", "n.oid = t.relnamespace").
+			Join("pg_attribute a", "a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)").
+			Where(in.schemaCondition("n.nspname"), querybuilder.Eq("t.relname", table), querybuilder.Expr("NOT ix.indisprimary")).
+			OrderBy("i.relname", "ASC").OrderByRaw("array_position(ix.indkey::int2[], a.attnum)")
+	case "mysql":
+		sb = in.builder().Select("index_name", "non_unique = 0", "column_name").From("information_schema.statistics").
+			Where(in.schemaCondition("table_schema"), querybuilder.Eq("table_name", table),
+				querybuilder.NotEq("index_name", "PRIMARY"), querybuilder.IsNotNull("column_name")).
+			OrderBy("index_name", "ASC").OrderBy("seq_in_index", "ASC")
+	case "sqlserver":
+		sb = in.builder().Select("i.name", "i.is_unique", "c.name").From("sys.indexes i").
+			Join("sys.index_columns ic", "ic.object_id = i.object_id AND ic.index_id = i.index_id").
+			Join("sys.columns c", "c.object_id = ic.object_id AND c.column_id = ic.column_id").
+			Where(querybuilder.Expr("i.object_id = OBJECT_ID(?)", in.objectName(table)),
+				querybuilder.Eq("i.is_primary_key", 0), querybuilder.Eq("ic.is_included_column", 0)).
+			OrderBy("i.name", "ASC").OrderBy("ic.key_ordinal", "ASC")
+	default:
+		sb = in.builder().Select("i.index_name", "CASE i.uniqueness WHEN 'UNIQUE' THEN 1 ELSE 0 END", "c.column_name").
+			From("user_indexes i").
+			Join("user_ind_columns c", "c.index_name = i.index_name").
+			Where(querybuilder.Eq("i.table_name", table),
+				querybuilder.Expr("i.index_name NOT IN (SELECT index_name FROM user_constraints WHERE constraint_type = 'P' AND index_name IS NOT NULL)")).
+			OrderBy("i.index_name", "ASC").OrderBy("c.column_position", "ASC")
+	}
+
+	var indexes []Index
+	err := in.query(ctx, sb, func(rows *sql.Rows) error {
+		var (
+			name, column string
+			unique       bool
+		)
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return err
+		}
+		if n := len(indexes); n > 0 && indexes[n-1].Name == name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+			return nil
+		}
+		indexes = append(indexes, Index{Name: name, Unique: unique, Columns: []string{column}})
+		return nil
+	})
+	return indexes, err
+}
+
+// sqliteIndexes reads the indexes of a SQLite table, one pragma per index
+func (in *Inspector) sqliteIndexes(ctx context.Context, table string) ([]Index, error) {
+	list := in.builder().Select("name", `"unique"`).FromFunction("pragma_index_list", table).
+		Where(querybuilder.NotEq("origin", "pk")).OrderBy("name", "ASC")
+
+	var indexes []Index
+	err := in.query(ctx, list, func(rows *sql.Rows) error {
+		var idx Index
+		if err := rows.Scan(&idx.Name, &idx.Unique); err != nil {
+			return err
+		}
+		indexes = append(indexes, idx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range indexes {
+		info := in.builder().Select("name").FromFunction("pragma_index_info", indexes[i].Name).
+			Where(querybuilder.IsNotNull("name")).OrderBy("seqno", "ASC")
+		err := in.query(ctx, info, func(rows *sql.Rows) error {
+			var column string
+			if err := rows.Scan(&column); err != nil {
+				return err
+			}
+			indexes[i].Columns = append(indexes[i].Columns, column)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return indexes, nil
+}
+
+// foreignKeys reads the foreign keys of a table
+func (in *Inspector) foreignKeys(ctx context.Context, table string) ([]ForeignKey, error) {
+	var sb querybuilder.SelectBuilder
+	switch querybuilder.DialectName(in.dialect) {
+	case "sqlite":
+		sb = in.builder().Select("id", `"from"`, `"table"`, `"to"`).FromFunction("pragma_foreign_key_list", table).
+			OrderBy("id", "ASC").OrderBy("seq", "ASC")
+	case "postgres":
+		sb = in.builder().Select("kcu.constraint_name", "kcu.column_name", "ref.table_name", "ref.column_name").
+			From("information_schema.referential_constraints rc").
+			Join("information_schema.key_column_usage kcu",
+				"kcu.constraint_schema = rc.constraint_schema AND kcu.constraint_name = rc.constraint_name").
+			Join("information_schema.key_column_usage ref",
+				"ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name AND ref.ordinal_position = kcu.position_in_unique_constraint").
+			Where(in.schemaCondition("kcu.table_schema"), querybuilder.Eq("kcu.table_name", table)).
+			OrderBy("kcu.constraint_name", "ASC").OrderBy("kcu.ordinal_position", "ASC")
+	case "mysql":
+		sb = in.builder().Select("constraint_name", "column_name", "referenced_table_name", "referenced_column_name").
+			From("information_schema.key_column_usage").
+			Where(in.schemaCondition("table_schema"), querybuilder.Eq("table_name", table),
+				querybuilder.IsNotNull("referenced_table_name")).
+			OrderBy("constraint_name", "ASC").OrderBy("ordinal_position", "ASC")
+	case "sqlserver":
+		sb = in.builder().Select("fk.name", "pc.name", "OBJECT_NAME(fkc.referenced_object_id)", "rc.name").
+			From("sys.foreign_keys fk").
+			Join("sys.foreign_key_columns fkc", "fkc.constraint_object_id = fk.object_id").
+			Join("sys.columns pc", "pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id").
+			Join("sys.columns rc", "rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id").
+			Where(querybuilder.Expr("fk.parent_object_id = OBJECT_ID(?)", in.objectName(table))).
+			OrderBy("fk.name", "ASC").OrderBy("fkc.constraint_column_id", "ASC")
+	default:
+		sb = in.builder().Select("c.constraint_name", "cc.column_name", "rc.table_name", "rc.column_name").
+			From("user_constraints c").
+			Join("user_cons_columns cc", "cc.constraint_name = c.constraint_name").
+			Join("user_cons_columns rc", "rc.constraint_name = c.r_constraint_name AND rc.position = cc.position").
+			Where(querybuilder.Eq("c.constraint_type", "R"), querybuilder.Eq("c.table_name", table)).
+			OrderBy("c.constraint_name", "ASC").OrderBy("cc.position", "ASC")
+	}
+
+	var (
+		keys []ForeignKey
+		last string
+	)
+	err := in.query(ctx, sb, func(rows *sql.Rows) error {
+		var name, column, refTable, refColumn string
+		if err := rows.Scan(&name, &column, &refTable, &refColumn); err != nil {
+			return err
+		}
+		if n := len(keys); n > 0 && last == name {
+			keys[n-1].Columns = append(keys[n-1].Columns, column)
+			keys[n-1].RefColumns = append(keys[n-1].RefColumns, refColumn)
+			return nil
+		}
+		last = name
+		if querybuilder.DialectName(in.dialect) == "sqlite" {
+			name = "" // pragma ids are not constraint names
+		}
+		keys = append(keys, ForeignKey{Name: name, Columns: []string{column}, RefTable: refTable, RefColumns: []string{refColumn}})
+		return nil
+	})
+	return keys, err
+}
@@ -0,0 +1,188 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// usesMerge reports whether the upsert is rendered as a MERGE statement,
+// for dialects without ON CONFLICT
+func (ib *insertBuilder) usesMerge() bool {
+	if ib.conflict == nil {
+		return false
+	}
+	switch ib.dialect.(type) {
+	case sqlserverDialect, oracleDialect:
+		return true
+	}
+	return false
+}
+
+// buildMerge writes the upsert as MERGE INTO ... USING. The inserted rows
+// become the source relation, matched against the table on the conflict
+// Target columns; DoUpdate is applied WHEN MATCHED and the rows are
+// inserted WHEN NOT MATCHED. Oracle selects the rows FROM dual and cannot
+// update the columns it matches on or return rows from a MERGE.
+func (ib *insertBuilder) buildMerge(query *strings.Builder) ([]any, error) {
+	if ib.conflict.Target == "" {
+		return nil, errors.New("MERGE upsert requires a conflict target")
+	}
+	if len(ib.columns) == 0 {
+		return nil, errors.New("MERGE upsert requires columns")
+	}
+	if ib.useDefaults {
+		return nil, errors.New("MERGE upsert does not support DEFAULT VALUES")
+	}
+	if ib.conflict.Constraint != "" || ib.conflict.TargetWhere != nil {
+		return nil, errors.New("MERGE upsert does not support constraint or target WHERE predicates")
+	}
+
+	targets := splitColumns(ib.conflict.Target)
+	_, isOracle := ib.dialect.(oracleDialect)
+	if isOracle {
+		if len(ib.returning) > 0 {
+			return nil, errors.New("RETURNING is not supported by Oracle MERGE")
+		}
+		for _, col := range targets {
+			if _, ok := ib.conflict.DoUpdate[col]; ok && !ib.conflict.DoNothing {
+				return nil, fmt.Errorf("oracle MERGE cannot update the conflict target column %s", col)
+			}
+		}
+	}
+
+	var args []any
+
+	query.WriteString("MERGE INTO ")
+	query.WriteString(escapeTableRef(ib.dialect, ib.table))
+	if isOracle {
+		query.WriteString(" target USING ")
+	} else {
+		query.WriteString(" WITH (HOLDLOCK) AS target USING ")
+	}
+
+	sourceArgs, err := ib.buildMergeSource(query)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, sourceArgs...)
+
+	query.WriteString(" ON (")
+	for i, col := range targets {
+		if i > 0 {
+			query.WriteString(" AND ")
+		}
+		query.WriteString("target." + col + " = source." + col)
+	}
+	query.WriteString(")")
+
+	if !ib.conflict.DoNothing && len(ib.conflict.DoUpdate) > 0 {
+		updateArgs, err := ib.buildMergeUpdate(query, isOracle)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, updateArgs...)
+	}
+
+	query.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	query.WriteString(strings.Join(ib.columns, ", "))
+	query.WriteString(") VALUES (")
+	for i, col := range ib.columns {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("source." + col)
+	}
+	query.WriteString(")")
+
+	if len(ib.returning) > 0 {
+		query.WriteString(" OUTPUT ")
+		for i, col := range ib.returning {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("INSERTED." + col)
+		}
+	}
+
+	return args, nil
+}
+
+// buildMergeUpdate writes the WHEN MATCHED branch. UpdateWhere becomes an
+// extra match condition on SQL Server and an UPDATE ... WHERE on Oracle.
+func (ib *insertBuilder) buildMergeUpdate(query *strings.Builder, isOracle bool) ([]any, error) {
+	var (
+		args      []any
+		whereSQL  string
+		whereArgs []any
+		err       error
+	)
+
+	query.WriteString(" WHEN MATCHED")
+	if ib.conflict.UpdateWhere != nil && !isOracle {
+		whereSQL, whereArgs, err = conditionToSQL(ib.conflict.UpdateWhere, ib.dialect, &ib.paramCounter)
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(" AND " + whereSQL)
+		args = append(args, whereArgs...)
+	}
+	query.WriteString(" THEN UPDATE SET ")
+
+	setArgs, err := ib.buildConflictAssignments(query)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, setArgs...)
+
+	if ib.conflict.UpdateWhere != nil && isOracle {
+		whereSQL, whereArgs, err = conditionToSQL(ib.conflict.UpdateWhere, ib.dialect, &ib.paramCounter)
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(" WHERE " + whereSQL)
+		args = append(args, whereArgs...)
+	}
+	return args, nil
+}
+
+// buildMergeSource writes the rows to upsert as the source relation
+func (ib *insertBuilder) buildMergeSource(query *strings.Builder) ([]any, error) {
+	if ib.fromSelect != nil {
+		selectSQL, selectArgs, err := ib.fromSelect.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString("(")
+		query.WriteString(shiftPlaceholders(selectSQL, ib.dialect, ib.paramCounter))
+		query.WriteString(")")
+		if _, ok := ib.dialect.(oracleDialect); ok {
+			query.WriteString(" source")
+		} else {
+			query.WriteString(" AS source (")
+			query.WriteString(strings.Join(ib.columns, ", "))
+			query.WriteString(")")
+		}
+		ib.paramCounter += len(selectArgs)
+		return selectArgs, nil
+	}
+
+	source := &valuesList{rows: ib.values, alias: "source", columns: ib.columns}
+	sourceSQL, sourceArgs, err := source.toSQL(ib.dialect, &ib.paramCounter)
+	if err != nil {
+		return nil, err
+	}
+	query.WriteString(sourceSQL)
+	return sourceArgs, nil
+}
+
+// splitColumns splits a comma separated column list such as "id, tenant_id"
+func splitColumns(columns string) []string {
+	var cols []string
+	for _, col := range strings.Split(columns, ",") {
+		if col = strings.TrimSpace(col); col != "" {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
@@ -0,0 +1,316 @@
+// Package migrations applies versioned schema migrations written with the
+// query builder. Applied versions are tracked in a schema_migrations table:
+//
+//	m := migrations.New(db, querybuilder.NewPostgreSQLDialect())
+//	m.Register(1, "create people", upPeople, downPeople)
+//	err := m.Migrate(ctx)
+package migrations
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// DefaultTable is the table recording applied versions
+const DefaultTable = "schema_migrations"
+
+// Func runs one direction of a migration inside a transaction, with a
+// builder bound to the migrator's dialect
+type Func func(ctx context.Context, tx *sql.Tx, qb querybuilder.Builder) error
+
+// Migration is a registered schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      Func
+	Down    Func
+}
+
+// Status reports whether a registered migration is applied
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time // zero when not applied
+}
+
+// Migrator applies and reverts migrations on a database
+type Migrator struct {
+	db         *sql.DB
+	dialect    querybuilder.Dialect
+	table      string
+	migrations []Migration
+	err        error // first registration error, returned by every run
+}
+
+// New creates a migrator for db using the dialect of its driver
+func New(db *sql.DB, dialect querybuilder.Dialect) *Migrator {
+	return &Migrator{db: db, dialect: dialect, table: DefaultTable}
+}
+
+// Table changes the table recording applied versions
+func (m *Migrator) Table(table string) *Migrator {
+	m.table = table
+	return m
+}
+
+// Register adds a migration. Versions must be positive and unique; down may
+// be nil for migrations that cannot be rolled back.
+func (m *Migrator) Register(version int64, name string, up, down Func) *Migrator {
+	switch {
+	case m.err != nil:
+	case version <= 0:
+		m.err = fmt.Errorf("migration %q: version must be positive", name)
+	case up == nil:
+		m.err = fmt.Errorf("migration %d: no up function", version)
+	case slices.ContainsFunc(m.migrations, func(mig Migration) bool { return mig.Version == version }):
+		m.err = fmt.Errorf("migration %d registered twice", version)
+	}
+	m.migrations = append(m.migrations, Migration{Version: version, Name: name, Up: up, Down: down})
+	slices.SortFunc(m.migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return m
+}
+
+// builder returns a query builder bound to the migrator's dialect
+func (m *Migrator) builder() querybuilder.Builder {
+	return querybuilder.New().WithDialect(m.dialect)
+}
+
+// Migrate applies every pending migration in version order, each in its
+// own transaction, and returns the number applied
+func (m *Migrator) Migrate(ctx context.Context) (int, error) {
+	applied, err := m.prepare(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		err := m.run(ctx, mig, mig.Up, func(qb querybuilder.Builder) querybuilder.SQLBuilder {
+			return qb.Insert(m.table).Columns("version", "name", "applied_at").
+				Values(mig.Version, mig.Name, time.Now().UTC())
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Rollback reverts the last steps applied migrations, newest first, and
+// returns the number reverted
+func (m *Migrator) Rollback(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, errors.New("rollback steps must be positive")
+	}
+	applied, err := m.prepare(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	slices.Reverse(versions)
+
+	count := 0
+	for _, version := range versions[:min(steps, len(versions))] {
+		i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == version })
+		if i < 0 {
+			return count, fmt.Errorf("applied migration %d is not registered", version)
+		}
+		mig := m.migrations[i]
+		if mig.Down == nil {
+			return count, fmt.Errorf("migration %d has no down function", version)
+		}
+		err := m.run(ctx, mig, mig.Down, func(qb querybuilder.Builder) querybuilder.SQLBuilder {
+			return qb.Delete(m.table).Where(querybuilder.Eq("version", version))
+		})
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Status lists the registered migrations in version order
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		appliedAt, ok := applied[mig.Version]
+		statuses = append(statuses, Status{Version: mig.Version, Name: mig.Name, Applied: ok, AppliedAt: appliedAt})
+	}
+	return statuses, nil
+}
+
+// run executes fn and records the change built by record in one transaction
+func (m *Migrator) run(ctx context.Context, mig Migration, fn Func, record func(querybuilder.Builder) querybuilder.SQLBuilder) error {
+	query, args, err := record(m.builder()).ToSQL()
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, tx, m.builder()); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("migration %d %s: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("migration %d %s: %w", mig.Version, mig.Name, err)
+	}
+	return tx.Commit()
+}
+
+// prepare creates the versions table when missing and returns the applied
+// versions with the time they were applied
+func (m *Migrator) prepare(ctx context.Context) (map[int64]time.Time, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+
+	query, args, err := m.builder().Select("version", "applied_at").From(m.table).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt scannedTime
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt.Time
+	}
+	return applied, rows.Err()
+}
+
+// createTable creates the versions table unless it exists
+func (m *Migrator) createTable(ctx context.Context) error {
+	bigint, varchar, timestamp := "BIGINT", "VARCHAR(255)", "TIMESTAMP"
+	ifNotExists := true
+
+	switch querybuilder.DialectName(m.dialect) {
+	case "sqlserver":
+		timestamp = "DATETIME2"
+		ifNotExists = false
+	case "oracle":
+		bigint, varchar = "NUMBER(19)", "VARCHAR2(255)"
+		ifNotExists = false
+	case "":
+		return errors.New("migrations require a built-in dialect")
+	}
+
+	exists, err := m.tableExists(ctx)
+	if err != nil || exists {
+		return err
+	}
+	cb := m.builder().CreateTable(m.table).
+		Column("version", bigint+" NOT NULL").
+		Column("name", varchar+" NOT NULL").
+		Column("applied_at", timestamp+" NOT NULL").
+		PrimaryKey("version")
+	if ifNotExists {
+		cb.IfNotExists()
+	}
+	query, args, err := cb.ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = m.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// tableExists looks the versions table up on SQL Server and Oracle, which
+// have no CREATE TABLE IF NOT EXISTS; other dialects report false
+func (m *Migrator) tableExists(ctx context.Context) (bool, error) {
+	var lookup querybuilder.SelectBuilder
+	switch querybuilder.DialectName(m.dialect) {
+	case "sqlserver":
+		lookup = m.builder().Select("COUNT(*)").From("INFORMATION_SCHEMA.TABLES").
+			Where(querybuilder.Eq("TABLE_NAME", m.table))
+	case "oracle":
+		lookup = m.builder().Select("COUNT(*)").From("user_tables").
+			Where(querybuilder.Eq("table_name", strings.ToUpper(m.table)))
+	default:
+		return false, nil
+	}
+
+	query, args, err := lookup.ToSQL()
+	if err != nil {
+		return false, err
+	}
+	var count int
+	if err := m.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// scannedTime scans applied_at whether the driver returns a time.Time or
+// text, as MySQL does unless the DSN sets parseTime=true
+type scannedTime struct {
+	time.Time
+}
+
+// timeLayouts are the text forms of applied_at returned by drivers
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+func (t *scannedTime) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot scan %T into applied_at", src)
+	}
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse applied_at %q", text)
+}
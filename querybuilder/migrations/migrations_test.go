@@ -0,0 +1,183 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/setiadijoe/go-utils/querybuilder"
+)
+
+// fakeDB is an in-memory database understanding the statements issued by
+// the migrator; every other statement is recorded in executed
+type fakeDB struct {
+	versions map[int64]time.Time
+	executed []string
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error)      { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                             { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                { return c, nil }
+func (c fakeConn) Commit() error                            { return nil }
+func (c fakeConn) Rollback() error                          { return nil }
+func (c fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(query, `CREATE TABLE IF NOT EXISTS "schema_migrations"`):
+	case strings.HasPrefix(query, `INSERT INTO "schema_migrations"`):
+		c.db.versions[args[0].Value.(int64)] = args[2].Value.(time.Time)
+	case strings.HasPrefix(query, `DELETE FROM "schema_migrations"`):
+		delete(c.db.versions, args[0].Value.(int64))
+	default:
+		c.db.executed = append(c.db.executed, query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, `SELECT version, applied_at FROM "schema_migrations"`) {
+		return nil, errors.New("unexpected query " + query)
+	}
+	rows := &fakeRows{}
+	for version, appliedAt := range c.db.versions {
+		rows.values = append(rows.values, []driver.Value{version, appliedAt})
+	}
+	return rows, nil
+}
+
+type fakeRows struct{ values [][]driver.Value }
+
+func (r *fakeRows) Columns() []string { return []string{"version", "applied_at"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// exec returns a migration function running the statement built by fn
+func exec(fn func(qb querybuilder.Builder) querybuilder.SQLBuilder) Func {
+	return func(ctx context.Context, tx *sql.Tx, qb querybuilder.Builder) error {
+		query, args, err := fn(qb).ToSQL()
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query, args...)
+		return err
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	fake := &fakeDB{versions: map[int64]time.Time{}}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+	ctx := context.Background()
+
+	m := New(db, querybuilder.NewPostgreSQLDialect()).
+		Register(2, "index people email",
+			exec(func(qb querybuilder.Builder) querybuilder.SQLBuilder {
+				return qb.CreateIndex("people_email_idx").On("people", "email")
+			}),
+			exec(func(qb querybuilder.Builder) querybuilder.SQLBuilder {
+				return qb.DropIndex("people_email_idx", "people")
+			})).
+		Register(1, "seed admin",
+			exec(func(qb querybuilder.Builder) querybuilder.SQLBuilder {
+				return qb.Insert("people").Columns("full_name").Values("admin")
+			}), nil)
+
+	applied, err := m.Migrate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 2 || len(fake.versions) != 2 {
+		t.Fatalf("expected 2 applied migrations, got %d (%d recorded)", applied, len(fake.versions))
+	}
+	if !strings.HasPrefix(fake.executed[0], `INSERT INTO "people"`) {
+		t.Errorf("expected version 1 to run first, got %q", fake.executed[0])
+	}
+
+	if applied, err := m.Migrate(ctx); err != nil || applied != 0 {
+		t.Errorf("expected nothing to apply, got %d, %v", applied, err)
+	}
+
+	reverted, err := m.Rollback(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.versions[2]; reverted != 1 || ok {
+		t.Errorf("expected version 2 to be rolled back, got %d reverted, versions %v", reverted, fake.versions)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("unexpected status %+v", statuses)
+	}
+	t.Logf("executed ===> %q  ====> status =====> %+v", fake.executed, statuses)
+
+	if _, err := m.Rollback(ctx, 1); err == nil {
+		t.Error("rolling back a migration without down should return error")
+	}
+}
+
+func TestMigratorRegisterErrors(t *testing.T) {
+	noop := func(context.Context, *sql.Tx, querybuilder.Builder) error { return nil }
+	tests := []struct {
+		name string
+		m    *Migrator
+	}{
+		{
+			name: "Duplicate Version",
+			m:    New(nil, querybuilder.NewPostgreSQLDialect()).Register(1, "a", noop, nil).Register(1, "b", noop, nil),
+		},
+		{
+			name: "Invalid Version",
+			m:    New(nil, querybuilder.NewPostgreSQLDialect()).Register(0, "a", noop, nil),
+		},
+		{
+			name: "Missing Up",
+			m:    New(nil, querybuilder.NewPostgreSQLDialect()).Register(1, "a", nil, noop),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.m.Migrate(context.Background()); err == nil {
+				t.Error("should return error")
+			}
+		})
+	}
+}
+
+func TestScannedTime(t *testing.T) {
+	want := time.Date(2026, 10, 18, 1, 2, 3, 0, time.UTC)
+	for _, src := range []any{want, []byte("2026-10-18 01:02:03"), "2026-10-18T01:02:03Z"} {
+		var got scannedTime
+		if err := got.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("scanning %v: expected %v, got %v", src, want, got.Time)
+		}
+	}
+	var got scannedTime
+	if err := got.Scan(int64(1)); err == nil {
+		t.Error("should return error for an unsupported type")
+	}
+}
@@ -0,0 +1,65 @@
+package querybuilder
+
+// NegatableCondition is a condition that can be inverted with Not, so
+// negation can be toggled at the call site:
+//
+//	cond := Eq("status", "archived")
+//	if !includeArchived {
+//		cond = cond.Not()
+//	}
+type NegatableCondition interface {
+	Condition
+	Not() NegatableCondition
+}
+
+// Not negates a condition, rendering NOT (condition). Negating a negated
+// condition returns the original one.
+func Not(condition Condition) NegatableCondition {
+	if n, ok := condition.(*notCondition); ok {
+		if inner, ok := n.condition.(NegatableCondition); ok {
+			return inner
+		}
+	}
+	return &notCondition{condition: condition}
+}
+
+// notCondition handles NOT (...) around another condition
+type notCondition struct {
+	condition Condition
+}
+
+func (c *notCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	sql, args, _ := c.ToSQLErr(dialect, argPos)
+	return sql, args
+}
+
+// ToSQLErr renders the negated condition, returning its errors. An empty
+// condition such as And() stays empty.
+func (c *notCondition) ToSQLErr(dialect Dialect, argPos *int) (string, []any, error) {
+	sql, args, err := conditionToSQL(c.condition, dialect, argPos)
+	if err != nil || sql == "" {
+		return sql, args, err
+	}
+	return "NOT (" + sql + ")", args, nil
+}
+
+func (c *notCondition) Not() NegatableCondition         { return Not(c) }
+func (c *baseCondition) Not() NegatableCondition        { return Not(c) }
+func (c *literalLikeCondition) Not() NegatableCondition { return Not(c) }
+func (c *ilikeCondition) Not() NegatableCondition       { return Not(c) }
+func (c *regexpCondition) Not() NegatableCondition      { return Not(c) }
+func (c *arrayCondition) Not() NegatableCondition       { return Not(c) }
+func (c *inCondition) Not() NegatableCondition          { return Not(c) }
+func (c *columnCondition) Not() NegatableCondition      { return Not(c) }
+func (c *betweenCondition) Not() NegatableCondition     { return Not(c) }
+func (c *nullSafeEqCondition) Not() NegatableCondition  { return Not(c) }
+func (c *bitAndCondition) Not() NegatableCondition      { return Not(c) }
+func (c *quantifiedCondition) Not() NegatableCondition  { return Not(c) }
+func (c *existsCondition) Not() NegatableCondition      { return Not(c) }
+func (c *exprCondition) Not() NegatableCondition        { return Not(c) }
+func (c *logicalCondition) Not() NegatableCondition     { return Not(c) }
+func (c *collatedCondition) Not() NegatableCondition    { return Not(c) }
+func (c *dateRangeCondition) Not() NegatableCondition   { return Not(c) }
+func (c *withinLastCondition) Not() NegatableCondition  { return Not(c) }
+func (c *spatialCondition) Not() NegatableCondition     { return Not(c) }
+func (f Fragment) Not() NegatableCondition              { return Not(f) }
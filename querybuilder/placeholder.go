@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	postgresPlaceholderRegex  = regexp.MustCompile(`\$(\d+)`)
+	sqlserverPlaceholderRegex = regexp.MustCompile(`@p(\d+)`)
+	oraclePlaceholderRegex    = regexp.MustCompile(`:(\d+)`)
+)
+
+// shiftPlaceholders renumbers the positional placeholders of a statement that
+// was built on its own (starting at the first placeholder) so that they
+// continue after offset arguments of the enclosing statement.
+// Dialects using anonymous placeholders ("?") are returned unchanged, and
+// text inside single-quoted string literals is left untouched.
+func shiftPlaceholders(sql string, dialect Dialect, offset int) string {
+	if offset == 0 {
+		return sql
+	}
+
+	var re *regexp.Regexp
+	switch dialect.(type) {
+	case postgresDialect:
+		re = postgresPlaceholderRegex
+	case sqlserverDialect:
+		re = sqlserverPlaceholderRegex
+	case oracleDialect:
+		re = oraclePlaceholderRegex
+	default:
+		return sql
+	}
+
+	shift := func(match string) string {
+		index, err := strconv.Atoi(re.FindStringSubmatch(match)[1])
+		if err != nil {
+			return match
+		}
+		return dialect.Placeholder(index - 1 + offset)
+	}
+
+	// Odd parts of the split are the contents of string literals
+	parts := strings.Split(sql, "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = re.ReplaceAllStringFunc(parts[i], shift)
+	}
+	return strings.Join(parts, "'")
+}
+
+// bindPlaceholders rewrites the "?" placeholders of a raw expression to the
+// dialect's placeholder style, numbering them from paramCount. Question marks
+// inside single-quoted string literals are left untouched.
+func bindPlaceholders(expr string, dialect Dialect, paramCount *int) string {
+	var (
+		sql      strings.Builder
+		inString bool
+	)
+
+	for _, r := range expr {
+		switch {
+		case r == '\'':
+			inString = !inString
+			sql.WriteRune(r)
+		case r == '?' && !inString:
+			sql.WriteString(dialect.Placeholder(*paramCount))
+			*paramCount++
+		default:
+			sql.WriteRune(r)
+		}
+	}
+
+	return sql.String()
+}
@@ -0,0 +1,42 @@
+package querybuilder
+
+import (
+	"strings"
+)
+
+// rawClause is a raw SQL fragment with bound args for its "?" placeholders
+// attached before or after a statement
+type rawClause struct {
+	sql  string
+	args []any
+}
+
+// toSQL renders the fragment, binding its placeholders only when it has args
+func (c rawClause) toSQL(dialect Dialect, paramCount *int) string {
+	if len(c.args) == 0 {
+		return c.sql
+	}
+	return bindPlaceholders(c.sql, dialect, paramCount)
+}
+
+// buildPrefixes writes the prefix clauses followed by a space and returns their args
+func buildPrefixes(query *strings.Builder, prefixes []rawClause, dialect Dialect, paramCount *int) []any {
+	var args []any
+	for _, p := range prefixes {
+		query.WriteString(p.toSQL(dialect, paramCount))
+		query.WriteString(" ")
+		args = append(args, p.args...)
+	}
+	return args
+}
+
+// buildSuffixes writes the suffix clauses preceded by a space and returns their args
+func buildSuffixes(query *strings.Builder, suffixes []rawClause, dialect Dialect, paramCount *int) []any {
+	var args []any
+	for _, s := range suffixes {
+		query.WriteString(" ")
+		query.WriteString(s.toSQL(dialect, paramCount))
+		args = append(args, s.args...)
+	}
+	return args
+}
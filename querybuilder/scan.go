@@ -0,0 +1,104 @@
+package querybuilder
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// scanInto scans rows into dest, a pointer to a struct receiving the first
+// row or a pointer to a slice of structs (or struct pointers) receiving
+// every row. Columns are matched to `db` tagged fields, ignoring case;
+// columns without a field are discarded. A struct dest with no row returns
+// sql.ErrNoRows.
+func scanInto(rows *sql.Rows, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("scan destination must be a non-nil pointer")
+	}
+	target := rv.Elem()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case target.Kind() == reflect.Struct:
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			return sql.ErrNoRows
+		}
+		return scanRow(rows, columns, target)
+
+	case target.Kind() == reflect.Slice && structType(target.Type().Elem()) != nil:
+		elemType := target.Type().Elem()
+		for rows.Next() {
+			elem := reflect.New(structType(elemType)).Elem()
+			if err := scanRow(rows, columns, elem); err != nil {
+				return err
+			}
+			if elemType.Kind() == reflect.Pointer {
+				elem = elem.Addr()
+			}
+			target.Set(reflect.Append(target, elem))
+		}
+		return rows.Err()
+
+	default:
+		return errors.New("scan destination must point to a struct or a slice of structs")
+	}
+}
+
+// structType returns the struct type of t or *t, or nil for other types
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// scanRow scans the current row into the fields of the struct value v
+func scanRow(rows *sql.Rows, columns []string, v reflect.Value) error {
+	fields := make(map[string][]int)
+	structFields(v.Type(), nil, fields)
+
+	targets := make([]any, len(columns))
+	for i, col := range columns {
+		index, ok := fields[strings.ToLower(col)]
+		if !ok {
+			targets[i] = new(any)
+			continue
+		}
+		targets[i] = v.FieldByIndex(index).Addr().Interface()
+	}
+	return rows.Scan(targets...)
+}
+
+// structFields maps the lower-cased `db` tag of every exported field of t,
+// including those of embedded structs, to its field index
+func structFields(t reflect.Type, parent []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+		tag, tagged := field.Tag.Lookup("db")
+
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			structFields(field.Type, index, fields)
+			continue
+		}
+		column, _, _ := strings.Cut(tag, ",")
+		if !tagged || column == "-" || column == "" || !field.IsExported() {
+			continue
+		}
+		if _, ok := fields[strings.ToLower(column)]; !ok {
+			fields[strings.ToLower(column)] = index
+		}
+	}
+}
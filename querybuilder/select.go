@@ -3,44 +3,135 @@ package querybuilder
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // SelectBuilder interface for chaining SELECT operations
 type SelectBuilder interface {
 	From(table string) SelectBuilder
+	FromAs(table, alias string) SelectBuilder
+	FromMany(tables ...string) SelectBuilder
+	FromValues(rows [][]any, alias string, columns ...string) SelectBuilder
+	FromFunction(name string, args ...any) SelectBuilder
+	AsOf(t time.Time) SelectBuilder
+	ForSystemTimeBetween(from, to time.Time) SelectBuilder
+	JoinValues(rows [][]any, alias, on string, columns ...string) SelectBuilder
+	LeftJoinValues(rows [][]any, alias, on string, columns ...string) SelectBuilder
+	Into(table string) SelectBuilder
+	As(alias string) SelectBuilder
 	Where(conditions ...Condition) SelectBuilder
+	WhereIf(ok bool, conditions ...Condition) SelectBuilder
+	WhereGroup(fn func(g ConditionGroup)) SelectBuilder
+	WhereMap(conditions map[string]any) SelectBuilder
+	WalkWhere(fn func(node ConditionNode) bool)
+	RewriteWhere(fn func(node ConditionNode) Condition) SelectBuilder
+	WhereExists(subq SQLBuilder) SelectBuilder
+	WhereNotExists(subq SQLBuilder) SelectBuilder
 	Join(table, on string) SelectBuilder
 	LeftJoin(table, on string) SelectBuilder
 	RightJoin(table, on string) SelectBuilder
+	JoinOn(table string, conditions ...Condition) SelectBuilder
+	LeftJoinOn(table string, conditions ...Condition) SelectBuilder
+	RightJoinOn(table string, conditions ...Condition) SelectBuilder
+	JoinUsing(table string, columns ...string) SelectBuilder
+	LeftJoinUsing(table string, columns ...string) SelectBuilder
+	RightJoinUsing(table string, columns ...string) SelectBuilder
 	GroupBy(columns ...string) SelectBuilder
+	GroupByRaw(expr string, args ...any) SelectBuilder
 	Having(conditions ...Condition) SelectBuilder
+	HavingIf(ok bool, conditions ...Condition) SelectBuilder
+	Qualify(conditions ...Condition) SelectBuilder
 	OrderBy(column string, direction string) SelectBuilder
+	OrderByIf(ok bool, column string, direction string) SelectBuilder
+	OrderByNulls(column string, direction string, nulls string) SelectBuilder
+	OrderByRaw(expr string, args ...any) SelectBuilder
+	OrderByCollate(column string, direction string, collation string) SelectBuilder
 	Limit(limit int) SelectBuilder
+	LimitIf(ok bool, limit int) SelectBuilder
+	LimitPercent(percent float64) SelectBuilder
+	LimitWithTies() SelectBuilder
 	Offset(offset int) SelectBuilder
+	OffsetIf(ok bool, offset int) SelectBuilder
+	UseIndex(indexes ...string) SelectBuilder
+	ForceIndex(indexes ...string) SelectBuilder
+	IgnoreIndex(indexes ...string) SelectBuilder
+	WithTableHint(hints ...string) SelectBuilder
+	SeekAfter(orderColumns []string, lastValues []any) SelectBuilder
+	SeekBefore(orderColumns []string, lastValues []any) SelectBuilder
 	Distinct() SelectBuilder
+	Comment(text string) SelectBuilder
+	Prefix(sql string, args ...any) SelectBuilder
+	Suffix(sql string, args ...any) SelectBuilder
+	Hint(text string) SelectBuilder
+	DistinctOn(columns ...string) SelectBuilder
+	SelectRaw(expr string, args ...any) SelectBuilder
+	SelectWindow(window WindowBuilder) SelectBuilder
+	SelectExpr(exprs ...SQLBuilder) SelectBuilder
+	ForUpdate() SelectBuilder
+	ForShare() SelectBuilder
+	SkipLocked() SelectBuilder
+	ForJSON(mode string, options ...string) SelectBuilder
+	ForXML(mode string, options ...string) SelectBuilder
+	NoWait() SelectBuilder
 	ToSQL() (string, []any, error)
+	ToCountSQL() (string, []any, error)
+	Clone() SelectBuilder
 	FromSubquery(subq SQLBuilder, alias string) SelectBuilder
 	JoinSubquery(subq SQLBuilder, alias, on string) SelectBuilder
 	LeftJoinSubquery(subq SQLBuilder, alias, on string) SelectBuilder
 	RightJoinSubquery(subq SQLBuilder, alias, on string) SelectBuilder
+	LateralJoin(subq SQLBuilder, alias, on string) SelectBuilder
+	LeftLateralJoin(subq SQLBuilder, alias, on string) SelectBuilder
+	With(alias string, builder SQLBuilder) SelectBuilder
+	WithRecursive(alias string, builder SQLBuilder) SelectBuilder
 }
 
 // selectBuilder implements SelectBuilder
 type selectBuilder struct {
-	dialect    Dialect
-	distinct   bool
-	columns    []string
-	table      string
-	joins      []join
-	where      []Condition
-	groupBy    []string
-	having     []Condition
-	orderBy    []order
-	limit      *int
-	offset     *int
-	paramCount int
-	subquery   *subquery
+	dialect     Dialect
+	distinct    bool
+	distinctOn  []string
+	columns     []selectColumn
+	table       string
+	tableAlias  string
+	extraTables []string
+	fromHints   tableHints
+	systemTime  *systemTime
+	into        string
+	joins       []join
+	where       []Condition
+	groupBy     []selectColumn
+	having      []Condition
+	qualify     []Condition
+	orderBy     []order
+	limit       *int
+	percent     *float64
+	withTies    bool
+	offset      *int
+	paramCount  int
+	subquery    *subquery
+	fromValues  *valuesList
+	fromFunc    *tableFunction
+	ctes        []cte
+	lockMode    string // "UPDATE", "SHARE"
+	lockWait    string // "NOWAIT", "SKIP LOCKED"
+	forOutput   string // e.g. "JSON PATH, ROOT('items')"
+	comments    []string
+	hints       []string
+	prefixes    []rawClause
+	suffixes    []rawClause
+	err         error // first error recorded while chaining, returned by ToSQL
+}
+
+// selectColumn is a single item of the select list (or GROUP BY list), either
+// an expression optionally carrying bound args for its "?" placeholders or a
+// nested builder
+type selectColumn struct {
+	expr    string
+	args    []any
+	builder SQLBuilder
 }
 
 // Subquery represents a subquery in FROM or JOIN clauses
@@ -49,24 +140,184 @@ type Subquery interface {
 }
 
 type join struct {
-	joinType  string
-	table     string
-	subquery  *subquery
-	condition string
+	joinType   string
+	table      string
+	subquery   *subquery
+	condition  string
+	conditions []Condition
+	using      []string
+	lateral    bool
+	hints      tableHints
+	values     *valuesList
 }
 
 // From specifies the table to select from
 func (sb *selectBuilder) From(table string) SelectBuilder {
+	sb.resetFrom()
 	sb.table = table
 	return sb
 }
 
+// FromAs specifies the table to select from together with its alias
+func (sb *selectBuilder) FromAs(table, alias string) SelectBuilder {
+	sb.resetFrom()
+	sb.table = table
+	sb.tableAlias = alias
+	return sb
+}
+
+// resetFrom clears the FROM source so that the last From* call wins
+func (sb *selectBuilder) resetFrom() {
+	sb.table = ""
+	sb.tableAlias = ""
+	sb.extraTables = nil
+	sb.subquery = nil
+	sb.fromFunc = nil
+	sb.fromValues = nil
+}
+
+// FromMany specifies several tables to select from as a comma-separated list
+func (sb *selectBuilder) FromMany(tables ...string) SelectBuilder {
+	if len(tables) == 0 {
+		return sb
+	}
+	sb.resetFrom()
+	sb.table = tables[0]
+	sb.extraTables = tables[1:]
+	return sb
+}
+
+// FromValues selects from a literal row set with the given alias and column names
+func (sb *selectBuilder) FromValues(rows [][]any, alias string, columns ...string) SelectBuilder {
+	sb.resetFrom()
+	sb.fromValues = &valuesList{rows: rows, alias: alias, columns: columns}
+	return sb
+}
+
+// FromFunction selects from a set-returning function such as UNNEST or
+// generate_series, binding args as parameters. Use As to alias it.
+func (sb *selectBuilder) FromFunction(name string, args ...any) SelectBuilder {
+	sb.resetFrom()
+	sb.fromFunc = &tableFunction{name: name, args: args}
+	return sb
+}
+
+// JoinValues adds an INNER JOIN against a literal row set
+func (sb *selectBuilder) JoinValues(rows [][]any, alias, on string, columns ...string) SelectBuilder {
+	return sb.joinValues("INNER", rows, alias, on, columns)
+}
+
+// LeftJoinValues adds a LEFT JOIN against a literal row set
+func (sb *selectBuilder) LeftJoinValues(rows [][]any, alias, on string, columns ...string) SelectBuilder {
+	return sb.joinValues("LEFT", rows, alias, on, columns)
+}
+
+func (sb *selectBuilder) joinValues(joinType string, rows [][]any, alias, on string, columns []string) SelectBuilder {
+	sb.joins = append(sb.joins, join{
+		joinType:  joinType,
+		values:    &valuesList{rows: rows, alias: alias, columns: columns},
+		condition: on,
+	})
+	return sb
+}
+
+// AsOf queries the FROM table as it was at the given point in time
+// (FOR SYSTEM_TIME AS OF on SQL Server temporal and MariaDB system-versioned tables)
+func (sb *selectBuilder) AsOf(t time.Time) SelectBuilder {
+	sb.systemTime = &systemTime{asOf: true, from: t}
+	return sb
+}
+
+// ForSystemTimeBetween queries every version of the FROM table rows that was
+// current between the given points in time
+func (sb *selectBuilder) ForSystemTimeBetween(from, to time.Time) SelectBuilder {
+	sb.systemTime = &systemTime{from: from, to: to}
+	return sb
+}
+
+// Into materializes the result into a new table with SELECT ... INTO
+// (SQL Server and PostgreSQL)
+func (sb *selectBuilder) Into(table string) SelectBuilder {
+	sb.into = table
+	return sb
+}
+
+// As sets the alias of the FROM table, subquery, function or VALUES list
+func (sb *selectBuilder) As(alias string) SelectBuilder {
+	if sb.fromValues != nil {
+		sb.fromValues.alias = alias
+		return sb
+	}
+	if sb.subquery != nil {
+		sb.subquery.alias = alias
+		return sb
+	}
+	if sb.fromFunc != nil {
+		sb.fromFunc.alias = alias
+		return sb
+	}
+	sb.tableAlias = alias
+	return sb
+}
+
 // Where adds WHERE conditions
 func (sb *selectBuilder) Where(conditions ...Condition) SelectBuilder {
 	sb.where = append(sb.where, conditions...)
 	return sb
 }
 
+// WhereIf adds WHERE conditions only when ok is true
+func (sb *selectBuilder) WhereIf(ok bool, conditions ...Condition) SelectBuilder {
+	if !ok {
+		return sb
+	}
+	return sb.Where(conditions...)
+}
+
+// WhereGroup adds the conditions collected by fn as a single WHERE
+// condition joined with AND; an empty group adds nothing
+func (sb *selectBuilder) WhereGroup(fn func(g ConditionGroup)) SelectBuilder {
+	if cond := Group(fn); cond != nil {
+		sb.where = append(sb.where, cond)
+	}
+	return sb
+}
+
+// WalkWhere visits the WHERE conditions with Walk, e.g. for middleware
+// checking that a tenant filter is present
+func (sb *selectBuilder) WalkWhere(fn func(node ConditionNode) bool) {
+	WalkConditions(sb.where, fn)
+}
+
+// RewriteWhere replaces the WHERE conditions with Rewrite, e.g. for
+// middleware narrowing a tenant filter
+func (sb *selectBuilder) RewriteWhere(fn func(node ConditionNode) Condition) SelectBuilder {
+	sb.where = RewriteConditions(sb.where, fn)
+	return sb
+}
+
+// WhereMap adds WHERE conditions parsed from a map, see ConditionsFromMap
+func (sb *selectBuilder) WhereMap(conditions map[string]any) SelectBuilder {
+	parsed, err := ConditionsFromMap(conditions)
+	if err != nil {
+		if sb.err == nil {
+			sb.err = err
+		}
+		return sb
+	}
+	return sb.Where(parsed...)
+}
+
+// WhereExists adds a WHERE EXISTS (subquery) condition
+func (sb *selectBuilder) WhereExists(subq SQLBuilder) SelectBuilder {
+	return sb.Where(Exists(subq))
+}
+
+// WhereNotExists adds a WHERE NOT EXISTS (subquery) condition
+func (sb *selectBuilder) WhereNotExists(subq SQLBuilder) SelectBuilder {
+	return sb.Where(NotExists(subq))
+}
+
 // Join adds an INNER JOIN
 func (sb *selectBuilder) Join(table, on string) SelectBuilder {
 	sb.joins = append(sb.joins, join{
@@ -97,9 +348,65 @@ func (sb *selectBuilder) RightJoin(table, on string) SelectBuilder {
 	return sb
 }
 
+// JoinOn adds an INNER JOIN whose ON clause is built from conditions
+func (sb *selectBuilder) JoinOn(table string, conditions ...Condition) SelectBuilder {
+	return sb.joinOn("INNER", table, conditions)
+}
+
+// LeftJoinOn adds a LEFT JOIN whose ON clause is built from conditions
+func (sb *selectBuilder) LeftJoinOn(table string, conditions ...Condition) SelectBuilder {
+	return sb.joinOn("LEFT", table, conditions)
+}
+
+// RightJoinOn adds a RIGHT JOIN whose ON clause is built from conditions
+func (sb *selectBuilder) RightJoinOn(table string, conditions ...Condition) SelectBuilder {
+	return sb.joinOn("RIGHT", table, conditions)
+}
+
+func (sb *selectBuilder) joinOn(joinType, table string, conditions []Condition) SelectBuilder {
+	sb.joins = append(sb.joins, join{
+		joinType:   joinType,
+		table:      table,
+		conditions: conditions,
+	})
+	return sb
+}
+
+// JoinUsing adds an INNER JOIN on the shared columns
+func (sb *selectBuilder) JoinUsing(table string, columns ...string) SelectBuilder {
+	return sb.joinUsing("INNER", table, columns)
+}
+
+// LeftJoinUsing adds a LEFT JOIN on the shared columns
+func (sb *selectBuilder) LeftJoinUsing(table string, columns ...string) SelectBuilder {
+	return sb.joinUsing("LEFT", table, columns)
+}
+
+// RightJoinUsing adds a RIGHT JOIN on the shared columns
+func (sb *selectBuilder) RightJoinUsing(table string, columns ...string) SelectBuilder {
+	return sb.joinUsing("RIGHT", table, columns)
+}
+
+func (sb *selectBuilder) joinUsing(joinType, table string, columns []string) SelectBuilder {
+	sb.joins = append(sb.joins, join{
+		joinType: joinType,
+		table:    table,
+		using:    columns,
+	})
+	return sb
+}
+
 // GroupBy adds GROUP BY columns
 func (sb *selectBuilder) GroupBy(columns ...string) SelectBuilder {
-	sb.groupBy = append(sb.groupBy, columns...)
+	for _, col := range columns {
+		sb.groupBy = append(sb.groupBy, selectColumn{expr: col})
+	}
+	return sb
+}
+
+// GroupByRaw adds a raw GROUP BY expression with bound args
+func (sb *selectBuilder) GroupByRaw(expr string, args ...any) SelectBuilder {
+	sb.groupBy = append(sb.groupBy, selectColumn{expr: expr, args: args})
 	return sb
 }
 
@@ -109,6 +416,20 @@ func (sb *selectBuilder) Having(conditions ...Condition) SelectBuilder {
 	return sb
 }
 
+// HavingIf adds HAVING conditions only when ok is true
+func (sb *selectBuilder) HavingIf(ok bool, conditions ...Condition) SelectBuilder {
+	if !ok {
+		return sb
+	}
+	return sb.Having(conditions...)
+}
+
+// Qualify adds QUALIFY conditions filtering on window function results
+func (sb *selectBuilder) Qualify(conditions ...Condition) SelectBuilder {
+	sb.qualify = append(sb.qualify, conditions...)
+	return sb
+}
+
 // OrderBy adds ORDER BY clause
 func (sb *selectBuilder) OrderBy(column string, direction string) SelectBuilder {
 	if direction != "ASC" && direction != "DESC" {
@@ -121,9 +442,80 @@ func (sb *selectBuilder) OrderBy(column string, direction string) SelectBuilder
 	return sb
 }
 
+// OrderByIf adds ORDER BY clause only when ok is true
+func (sb *selectBuilder) OrderByIf(ok bool, column string, direction string) SelectBuilder {
+	if !ok {
+		return sb
+	}
+	return sb.OrderBy(column, direction)
+}
+
+// OrderByNulls adds ORDER BY clause with NULLS FIRST or NULLS LAST placement
+func (sb *selectBuilder) OrderByNulls(column string, direction string, nulls string) SelectBuilder {
+	if direction != "ASC" && direction != "DESC" {
+		direction = "ASC"
+	}
+	if nulls != "FIRST" && nulls != "LAST" {
+		nulls = ""
+	}
+	sb.orderBy = append(sb.orderBy, order{
+		column:    column,
+		direction: direction,
+		nulls:     nulls,
+	})
+	return sb
+}
+
+// OrderByCollate adds ORDER BY clause sorting the column with the given collation
+func (sb *selectBuilder) OrderByCollate(column string, direction string, collation string) SelectBuilder {
+	if direction != "ASC" && direction != "DESC" {
+		direction = "ASC"
+	}
+	sb.orderBy = append(sb.orderBy, order{
+		column:    column,
+		direction: direction,
+		collation: collation,
+	})
+	return sb
+}
+
+// OrderByRaw adds a raw ORDER BY expression with bound args
+func (sb *selectBuilder) OrderByRaw(expr string, args ...any) SelectBuilder {
+	sb.orderBy = append(sb.orderBy, order{
+		column: expr,
+		raw:    true,
+		args:   args,
+	})
+	return sb
+}
+
 // Limit sets the LIMIT
 func (sb *selectBuilder) Limit(limit int) SelectBuilder {
 	sb.limit = &limit
+	sb.percent = nil
+	return sb
+}
+
+// LimitIf sets the LIMIT only when ok is true
+func (sb *selectBuilder) LimitIf(ok bool, limit int) SelectBuilder {
+	if !ok {
+		return sb
+	}
+	return sb.Limit(limit)
+}
+
+// LimitPercent limits the result to the given percentage of its rows
+// (TOP (n) PERCENT on SQL Server, FETCH NEXT n PERCENT ROWS on Oracle)
+func (sb *selectBuilder) LimitPercent(percent float64) SelectBuilder {
+	sb.percent = &percent
+	sb.limit = nil
+	return sb
+}
+
+// LimitWithTies makes the limit also return the rows tied with the last one
+// on the ORDER BY columns (SQL Server and Oracle only)
+func (sb *selectBuilder) LimitWithTies() SelectBuilder {
+	sb.withTies = true
 	return sb
 }
 
@@ -133,25 +525,293 @@ func (sb *selectBuilder) Offset(offset int) SelectBuilder {
 	return sb
 }
 
+// OffsetIf sets the OFFSET only when ok is true
+func (sb *selectBuilder) OffsetIf(ok bool, offset int) SelectBuilder {
+	if !ok {
+		return sb
+	}
+	return sb.Offset(offset)
+}
+
+// UseIndex adds a MySQL USE INDEX hint to the FROM table, or to the most
+// recently added join when called after a join
+func (sb *selectBuilder) UseIndex(indexes ...string) SelectBuilder {
+	sb.currentHints().addIndexHint("USE", indexes)
+	return sb
+}
+
+// ForceIndex adds a MySQL FORCE INDEX hint to the FROM table, or to the most
+// recently added join when called after a join
+func (sb *selectBuilder) ForceIndex(indexes ...string) SelectBuilder {
+	sb.currentHints().addIndexHint("FORCE", indexes)
+	return sb
+}
+
+// IgnoreIndex adds a MySQL IGNORE INDEX hint to the FROM table, or to the
+// most recently added join when called after a join
+func (sb *selectBuilder) IgnoreIndex(indexes ...string) SelectBuilder {
+	sb.currentHints().addIndexHint("IGNORE", indexes)
+	return sb
+}
+
+// WithTableHint adds SQL Server table hints such as NOLOCK to the FROM table,
+// or to the most recently added join when called after a join
+func (sb *selectBuilder) WithTableHint(hints ...string) SelectBuilder {
+	h := sb.currentHints()
+	h.table = append(h.table, hints...)
+	return sb
+}
+
+// currentHints returns the hints of the most recently added table reference
+func (sb *selectBuilder) currentHints() *tableHints {
+	if len(sb.joins) > 0 {
+		return &sb.joins[len(sb.joins)-1].hints
+	}
+	return &sb.fromHints
+}
+
+// SeekAfter adds keyset pagination: rows ordered ascending by orderColumns
+// that come after the row holding lastValues
+func (sb *selectBuilder) SeekAfter(orderColumns []string, lastValues []any) SelectBuilder {
+	return sb.seek(orderColumns, lastValues, "ASC")
+}
+
+// SeekBefore adds keyset pagination: rows ordered descending by orderColumns
+// that come before the row holding lastValues
+func (sb *selectBuilder) SeekBefore(orderColumns []string, lastValues []any) SelectBuilder {
+	return sb.seek(orderColumns, lastValues, "DESC")
+}
+
+func (sb *selectBuilder) seek(orderColumns []string, lastValues []any, direction string) SelectBuilder {
+	if len(orderColumns) == 0 || len(orderColumns) != len(lastValues) {
+		if sb.err == nil {
+			sb.err = fmt.Errorf("seek requires matching order columns (%d) and last values (%d)",
+				len(orderColumns), len(lastValues))
+		}
+		return sb
+	}
+	operator := GreatThan
+	if direction == "DESC" {
+		operator = LessTnan
+	}
+	sb.where = append(sb.where, &seekCondition{
+		columns:  orderColumns,
+		values:   lastValues,
+		operator: operator,
+	})
+	for _, col := range orderColumns {
+		sb.OrderBy(col, direction)
+	}
+	return sb
+}
+
+// With adds a common table expression to the WITH clause
+func (sb *selectBuilder) With(alias string, builder SQLBuilder) SelectBuilder {
+	sb.ctes = append(sb.ctes, cte{alias: alias, builder: builder})
+	return sb
+}
+
+// WithRecursive adds a recursive common table expression to the WITH clause
+func (sb *selectBuilder) WithRecursive(alias string, builder SQLBuilder) SelectBuilder {
+	sb.ctes = append(sb.ctes, cte{alias: alias, builder: builder, recursive: true})
+	return sb
+}
+
+// SelectRaw adds a raw expression with bound args to the select list
+func (sb *selectBuilder) SelectRaw(expr string, args ...any) SelectBuilder {
+	sb.columns = append(sb.columns, selectColumn{expr: expr, args: args})
+	return sb
+}
+
+// SelectWindow adds a window function expression to the select list
+func (sb *selectBuilder) SelectWindow(window WindowBuilder) SelectBuilder {
+	sb.columns = append(sb.columns, selectColumn{builder: window})
+	return sb
+}
+
+// ForUpdate locks the selected rows for update
+func (sb *selectBuilder) ForUpdate() SelectBuilder {
+	sb.lockMode = "UPDATE"
+	return sb
+}
+
+// ForShare locks the selected rows in share mode
+func (sb *selectBuilder) ForShare() SelectBuilder {
+	sb.lockMode = "SHARE"
+	return sb
+}
+
+// SkipLocked skips rows that are already locked instead of waiting
+func (sb *selectBuilder) SkipLocked() SelectBuilder {
+	sb.lockWait = "SKIP LOCKED"
+	return sb
+}
+
+// NoWait fails immediately instead of waiting for locked rows
+func (sb *selectBuilder) NoWait() SelectBuilder {
+	sb.lockWait = "NOWAIT"
+	return sb
+}
+
+// Comment prefixes the statement with a /* ... */ comment
+func (sb *selectBuilder) Comment(text string) SelectBuilder {
+	sb.comments = append(sb.comments, text)
+	return sb
+}
+
+// Hint adds an optimizer hint emitted as /*+ ... */ right after SELECT
+func (sb *selectBuilder) Hint(text string) SelectBuilder {
+	sb.hints = append(sb.hints, text)
+	return sb
+}
+
+// Prefix adds a raw SQL fragment with bound args before the statement
+func (sb *selectBuilder) Prefix(sql string, args ...any) SelectBuilder {
+	sb.prefixes = append(sb.prefixes, rawClause{sql: sql, args: args})
+	return sb
+}
+
+// Suffix adds a raw SQL fragment with bound args after the statement
+func (sb *selectBuilder) Suffix(sql string, args ...any) SelectBuilder {
+	sb.suffixes = append(sb.suffixes, rawClause{sql: sql, args: args})
+	return sb
+}
+
+// DistinctOn sets the DISTINCT ON columns (PostgreSQL only)
+func (sb *selectBuilder) DistinctOn(columns ...string) SelectBuilder {
+	sb.distinctOn = append(sb.distinctOn, columns...)
+	return sb
+}
+
+// SelectExpr adds expressions such as aggregates to the select list
+func (sb *selectBuilder) SelectExpr(exprs ...SQLBuilder) SelectBuilder {
+	for _, expr := range exprs {
+		sb.columns = append(sb.columns, selectColumn{builder: expr})
+	}
+	return sb
+}
+
 // Distinct sets the DISTINCT flag
 func (sb *selectBuilder) Distinct() SelectBuilder {
 	sb.distinct = true
 	return sb
 }
 
+// Clone returns a deep copy of the builder that can be modified independently
+func (sb *selectBuilder) Clone() SelectBuilder {
+	cloned := *sb
+	cloned.columns = slices.Clone(sb.columns)
+	for i := range cloned.columns {
+		cloned.columns[i].args = slices.Clone(cloned.columns[i].args)
+		if cloned.columns[i].builder != nil {
+			cloned.columns[i].builder = cloneBuilder(cloned.columns[i].builder)
+		}
+	}
+	cloned.distinctOn = slices.Clone(sb.distinctOn)
+	cloned.extraTables = slices.Clone(sb.extraTables)
+	cloned.fromHints = sb.fromHints.clone()
+	cloned.joins = cloneJoins(sb.joins)
+	cloned.where = slices.Clone(sb.where)
+	cloned.groupBy = slices.Clone(sb.groupBy)
+	for i := range cloned.groupBy {
+		cloned.groupBy[i].args = slices.Clone(cloned.groupBy[i].args)
+	}
+	cloned.having = slices.Clone(sb.having)
+	cloned.qualify = slices.Clone(sb.qualify)
+	cloned.orderBy = cloneOrders(sb.orderBy)
+	cloned.limit = cloneInt(sb.limit)
+	cloned.percent = cloneFloat(sb.percent)
+	cloned.offset = cloneInt(sb.offset)
+	cloned.subquery = sb.subquery.clone()
+	cloned.fromValues = sb.fromValues.clone()
+	cloned.fromFunc = sb.fromFunc.clone()
+	if sb.systemTime != nil {
+		st := *sb.systemTime
+		cloned.systemTime = &st
+	}
+	cloned.ctes = cloneCTEs(sb.ctes)
+	cloned.comments = slices.Clone(sb.comments)
+	cloned.hints = slices.Clone(sb.hints)
+	cloned.prefixes = cloneRawClauses(sb.prefixes)
+	cloned.suffixes = cloneRawClauses(sb.suffixes)
+	return &cloned
+}
+
 // ToSQL generates the SQL query and returns the query and parameters
 func (sb *selectBuilder) ToSQL() (string, []any, error) {
-	if sb.table == "" && sb.subquery == nil {
+	if sb.err != nil {
+		return "", nil, sb.err
+	}
+	if sb.table == "" && sb.subquery == nil && sb.fromValues == nil && sb.fromFunc == nil {
 		return "", nil, errors.New("no table or subquery specified for FROM clause")
 	}
+	if len(sb.qualify) > 0 && !supportsQualify(sb.dialect) {
+		return sb.qualifyWrapper().ToSQL()
+	}
+	if err := sb.validateLock(); err != nil {
+		return "", nil, err
+	}
+	if err := sb.validateLimit(); err != nil {
+		return "", nil, err
+	}
+	if _, ok := sb.dialect.(sqlserverDialect); sb.forOutput != "" && !ok {
+		return "", nil, errors.New("FOR JSON and FOR XML are only supported by SQL Server")
+	}
+	if err := sb.validateHints(); err != nil {
+		return "", nil, err
+	}
+	if sb.systemTime != nil {
+		if sb.table == "" {
+			return "", nil, errors.New("FOR SYSTEM_TIME requires a table in the FROM clause")
+		}
+		if err := sb.systemTime.validate(sb.dialect); err != nil {
+			return "", nil, err
+		}
+	}
+	if _, ok := sb.dialect.(postgresDialect); len(sb.distinctOn) > 0 && !ok {
+		return "", nil, errors.New("DISTINCT ON is only supported by PostgreSQL")
+	}
+	if sb.into != "" {
+		switch sb.dialect.(type) {
+		case sqlserverDialect, postgresDialect:
+		default:
+			return "", nil, errors.New("SELECT ... INTO is only supported by SQL Server and PostgreSQL, use CreateTableAs")
+		}
+	}
 
 	var (
 		query strings.Builder
 		args  []any
 	)
+	sb.paramCount = 0
+
+	// Prefix clauses
+	prefixArgs := buildPrefixes(&query, sb.prefixes, sb.dialect, &sb.paramCount)
+	args = append(args, prefixArgs...)
+
+	// Comments
+	buildComments(&query, sb.comments)
+
+	// WITH clause
+	withArgs, err := buildWithClause(&query, sb.ctes, sb.dialect, &sb.paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, withArgs...)
+	bodyStart := query.Len()
 
 	// SELECT clause
-	sb.buildSelectClause(&query)
+	selectArgs, err := sb.buildSelectClause(&query)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, selectArgs...)
+
+	// INTO clause
+	if sb.into != "" {
+		query.WriteString(" INTO ")
+		query.WriteString(sb.into)
+	}
 
 	// FROM clause
 	fromArgs, err := sb.buildFromClause(&query)
@@ -168,36 +828,130 @@ func (sb *selectBuilder) ToSQL() (string, []any, error) {
 	args = append(args, joinArgs...)
 
 	// WHERE clause
-	whereArgs := sb.buildWhereClause(&query)
+	whereArgs, err := sb.buildWhereClause(&query)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, whereArgs...)
 
 	// GROUP BY clause
-	sb.buildGroupByClause(&query)
+	groupByArgs := sb.buildGroupByClause(&query)
+	args = append(args, groupByArgs...)
 
 	// HAVING clause
-	havingArgs := sb.buildHavingClause(&query)
+	havingArgs, err := sb.buildHavingClause(&query)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, havingArgs...)
 
+	// QUALIFY clause
+	qualifyArgs, err := sb.buildQualifyClause(&query)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, qualifyArgs...)
+
 	// ORDER BY clause
-	sb.buildOrderByClause(&query)
+	orderByArgs := sb.buildOrderByClause(&query)
+	args = append(args, orderByArgs...)
+
+	// LIMIT / OFFSET clause
+	if sb.useRowNum() {
+		body := query.String()
+		query.Reset()
+		query.WriteString(body[:bodyStart])
+		rowNumArgs := sb.buildRowNumWrapper(&query, body[bodyStart:])
+		args = append(args, rowNumArgs...)
+	} else {
+		paginationArgs := sb.buildPaginationClause(&query)
+		args = append(args, paginationArgs...)
+	}
 
-	// LIMIT clause
-	limitArgs := sb.buildLimitClause(&query)
-	args = append(args, limitArgs...)
+	// FOR JSON / FOR XML clause
+	sb.buildForOutputClause(&query)
 
-	// OFFSET clause
-	offsetArgs := sb.buildOffsetClause(&query)
-	args = append(args, offsetArgs...)
+	// FOR UPDATE / FOR SHARE clause
+	sb.buildLockClause(&query)
+
+	// Suffix clauses
+	suffixArgs := buildSuffixes(&query, sb.suffixes, sb.dialect, &sb.paramCount)
+	args = append(args, suffixArgs...)
 
 	return query.String(), args, nil
 }
 
-// buildSelectClause builds the SELECT clause.
-func (sb *selectBuilder) buildSelectClause(query *strings.Builder) {
+// ToCountSQL generates a SELECT COUNT(*) query over the same FROM, JOIN and
+// WHERE clauses, dropping ordering and pagination. Grouped, qualified or
+// distinct queries are wrapped in a subquery so that the number of result rows is counted.
+func (sb *selectBuilder) ToCountSQL() (string, []any, error) {
+	counted := sb.Clone().(*selectBuilder)
+	counted.orderBy = nil
+	counted.limit = nil
+	counted.percent = nil
+	counted.withTies = false
+	counted.offset = nil
+	counted.lockMode = ""
+	counted.lockWait = ""
+	counted.into = ""
+	counted.forOutput = ""
+
+	if len(counted.groupBy) == 0 && len(counted.having) == 0 && len(counted.qualify) == 0 &&
+		!counted.distinct && len(counted.distinctOn) == 0 {
+		counted.columns = []selectColumn{{expr: "COUNT(*)"}}
+		return counted.ToSQL()
+	}
+
+	wrapper := &selectBuilder{
+		dialect:  sb.dialect,
+		columns:  []selectColumn{{expr: "COUNT(*)"}},
+		subquery: &subquery{builder: counted, alias: "count_query"},
+		ctes:     counted.ctes,
+		comments: counted.comments,
+		prefixes: counted.prefixes,
+		suffixes: counted.suffixes,
+	}
+	counted.ctes = nil
+	counted.comments = nil
+	counted.prefixes = nil
+	counted.suffixes = nil
+	return wrapper.ToSQL()
+}
+
+// buildSelectClause builds the SELECT clause and returns its args.
+func (sb *selectBuilder) buildSelectClause(query *strings.Builder) ([]any, error) {
+	var args []any
 	query.WriteString("SELECT ")
-	if sb.distinct {
+	if len(sb.hints) > 0 {
+		query.WriteString("/*+ ")
+		for _, h := range sb.hints {
+			query.WriteString(sanitizeComment(h))
+			query.WriteString(" ")
+		}
+		query.WriteString("*/ ")
+	}
+	if len(sb.distinctOn) > 0 {
+		query.WriteString("DISTINCT ON (")
+		query.WriteString(strings.Join(sb.distinctOn, ", "))
+		query.WriteString(") ")
+	} else if sb.distinct {
 		query.WriteString("DISTINCT ")
 	}
+	if sb.useTop() {
+		query.WriteString("TOP (")
+		query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+		query.WriteString(") ")
+		sb.paramCount++
+		if sb.percent != nil {
+			query.WriteString("PERCENT ")
+			args = append(args, *sb.percent)
+		} else {
+			args = append(args, *sb.limit)
+		}
+		if sb.withTies {
+			query.WriteString("WITH TIES ")
+		}
+	}
 	if len(sb.columns) == 0 {
 		query.WriteString("*")
 	} else {
@@ -205,106 +959,482 @@ func (sb *selectBuilder) buildSelectClause(query *strings.Builder) {
 			if i > 0 {
 				query.WriteString(", ")
 			}
-			query.WriteString(col)
+			switch {
+			case col.builder != nil:
+				colSQL, colArgs, err := col.builder.ToSQL()
+				if err != nil {
+					return nil, err
+				}
+				query.WriteString(shiftPlaceholders(colSQL, sb.dialect, sb.paramCount))
+				args = append(args, colArgs...)
+				sb.paramCount += len(colArgs)
+			case len(col.args) > 0:
+				query.WriteString(bindPlaceholders(col.expr, sb.dialect, &sb.paramCount))
+				args = append(args, col.args...)
+			default:
+				query.WriteString(col.expr)
+			}
 		}
 	}
+	return args, nil
 }
 
 // buildFromClause builds the FROM clause and returns its args.
 func (sb *selectBuilder) buildFromClause(query *strings.Builder) ([]any, error) {
 	var args []any
 	query.WriteString(" FROM ")
-	if sb.subquery != nil {
-		subSQL, subArgs, err := sb.subquery.ToSQL()
+	if sb.fromValues != nil {
+		valuesSQL, valuesArgs, err := sb.fromValues.toSQL(sb.dialect, &sb.paramCount)
 		if err != nil {
 			return nil, err
 		}
-		query.WriteString(subSQL)
-		if sb.subquery.alias != "" {
-			query.WriteString(" AS ")
-			query.WriteString(sb.subquery.alias)
+		query.WriteString(valuesSQL)
+		args = append(args, valuesArgs...)
+	} else if sb.fromFunc != nil {
+		funcSQL, funcArgs, err := sb.fromFunc.toSQL(sb.dialect, &sb.paramCount)
+		if err != nil {
+			return nil, err
 		}
+		query.WriteString(funcSQL)
+		args = append(args, funcArgs...)
+	} else if sb.subquery != nil {
+		subSQL, subArgs, err := sb.subquery.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(shiftPlaceholders(subSQL, sb.dialect, sb.paramCount))
+		query.WriteString(derivedTableAlias(sb.dialect, sb.subquery.alias))
 		args = append(args, subArgs...)
+		sb.paramCount += len(subArgs)
+	} else if sb.tableAlias != "" {
+		query.WriteString(escapeTableRef(sb.dialect, sb.table))
+		args = append(args, sb.buildSystemTimeClause(query)...)
+		query.WriteString(" ")
+		query.WriteString(escapeIdentifier(sb.dialect, sb.tableAlias))
+		query.WriteString(sb.fromHints.toSQL(sb.lockHints()...))
 	} else {
-		query.WriteString(sb.table)
+		query.WriteString(escapeTableRef(sb.dialect, sb.table))
+		args = append(args, sb.buildSystemTimeClause(query)...)
+		query.WriteString(sb.fromHints.toSQL(sb.lockHints()...))
+	}
+	for _, table := range sb.extraTables {
+		query.WriteString(", ")
+		query.WriteString(escapeTableRef(sb.dialect, table))
 	}
 	return args, nil
 }
 
+// derivedTableAlias renders the alias of a subquery in FROM or JOIN. Oracle
+// rejects AS before a table alias, so it is left out there.
+func derivedTableAlias(dialect Dialect, alias string) string {
+	if alias == "" {
+		return ""
+	}
+	if _, ok := dialect.(oracleDialect); ok {
+		return " " + alias
+	}
+	return " AS " + alias
+}
+
+// buildSystemTimeClause builds the FOR SYSTEM_TIME clause of the FROM table
+// and returns its args.
+func (sb *selectBuilder) buildSystemTimeClause(query *strings.Builder) []any {
+	if sb.systemTime == nil {
+		return nil
+	}
+	systemTimeSQL, systemTimeArgs := sb.systemTime.toSQL(sb.dialect, &sb.paramCount)
+	query.WriteString(systemTimeSQL)
+	return systemTimeArgs
+}
+
 // buildJoinClauses builds JOIN clauses and returns their args.
 func (sb *selectBuilder) buildJoinClauses(query *strings.Builder) ([]any, error) {
 	var args []any
 	for _, j := range sb.joins {
+		if len(j.using) > 0 {
+			if err := sb.buildJoinUsing(query, j); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if j.lateral {
+			lateralArgs, err := sb.buildLateralJoin(query, j)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, lateralArgs...)
+			continue
+		}
 		query.WriteString(fmt.Sprintf(" %s JOIN ", j.joinType))
-		if j.subquery != nil {
-			subSQL, subArgs, err := j.subquery.ToSQL()
+		if j.values != nil {
+			valuesSQL, valuesArgs, err := j.values.toSQL(sb.dialect, &sb.paramCount)
 			if err != nil {
 				return nil, err
 			}
-			query.WriteString(subSQL)
-			if j.subquery.alias != "" {
-				query.WriteString(" AS ")
-				query.WriteString(j.subquery.alias)
+			query.WriteString(valuesSQL)
+			args = append(args, valuesArgs...)
+		} else if j.subquery != nil {
+			subSQL, subArgs, err := j.subquery.ToSQL()
+			if err != nil {
+				return nil, err
 			}
+			query.WriteString(shiftPlaceholders(subSQL, sb.dialect, sb.paramCount))
+			query.WriteString(derivedTableAlias(sb.dialect, j.subquery.alias))
 			args = append(args, subArgs...)
+			sb.paramCount += len(subArgs)
 		} else {
-			query.WriteString(j.table)
+			query.WriteString(escapeTableRef(sb.dialect, j.table))
 		}
+		query.WriteString(j.hints.toSQL())
 		query.WriteString(" ON ")
-		query.WriteString(j.condition)
+		if len(j.conditions) > 0 {
+			onSQL, onArgs, err := buildConditions(j.conditions, sb.dialect, &sb.paramCount)
+			if err != nil {
+				return nil, err
+			}
+			query.WriteString(onSQL)
+			args = append(args, onArgs...)
+		} else {
+			query.WriteString(j.condition)
+		}
 	}
 	return args, nil
 }
 
+// buildJoinUsing builds a JOIN ... USING (columns) clause.
+func (sb *selectBuilder) buildJoinUsing(query *strings.Builder, j join) error {
+	if _, ok := sb.dialect.(sqlserverDialect); ok {
+		return errors.New("JOIN ... USING is not supported by SQL Server")
+	}
+	query.WriteString(fmt.Sprintf(" %s JOIN ", j.joinType))
+	query.WriteString(escapeTableRef(sb.dialect, j.table))
+	query.WriteString(j.hints.toSQL())
+	query.WriteString(" USING (")
+	for i, col := range j.using {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(escapeIdentifier(sb.dialect, col))
+	}
+	query.WriteString(")")
+	return nil
+}
+
+// buildLateralJoin builds a JOIN LATERAL clause, or CROSS / OUTER APPLY on
+// dialects that express correlated joins that way. APPLY has no ON clause, so
+// the correlation must live inside the subquery there.
+func (sb *selectBuilder) buildLateralJoin(query *strings.Builder, j join) ([]any, error) {
+	subSQL, subArgs, err := j.subquery.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	subSQL = shiftPlaceholders(subSQL, sb.dialect, sb.paramCount)
+	sb.paramCount += len(subArgs)
+
+	switch sb.dialect.(type) {
+	case sqliteDialect:
+		return nil, errors.New("lateral joins are not supported by SQLite")
+	case sqlserverDialect, oracleDialect:
+		if j.condition != "" {
+			return nil, errors.New("APPLY does not take an ON condition, correlate inside the subquery instead")
+		}
+		if j.joinType == "LEFT" {
+			query.WriteString(" OUTER APPLY ")
+		} else {
+			query.WriteString(" CROSS APPLY ")
+		}
+		query.WriteString(subSQL)
+		if j.subquery.alias != "" {
+			query.WriteString(" ")
+			query.WriteString(j.subquery.alias)
+		}
+	default:
+		query.WriteString(fmt.Sprintf(" %s JOIN LATERAL ", j.joinType))
+		query.WriteString(subSQL)
+		if j.subquery.alias != "" {
+			query.WriteString(" AS ")
+			query.WriteString(j.subquery.alias)
+		}
+		query.WriteString(" ON ")
+		if j.condition != "" {
+			query.WriteString(j.condition)
+		} else {
+			query.WriteString("TRUE")
+		}
+	}
+	return subArgs, nil
+}
+
 // buildWhereClause builds the WHERE clause and returns its args.
-func (sb *selectBuilder) buildWhereClause(query *strings.Builder) ([]any) {
+func (sb *selectBuilder) buildWhereClause(query *strings.Builder) ([]any, error) {
 	if len(sb.where) == 0 {
-		return nil
+		return nil, nil
+	}
+	whereSQL, whereArgs, err := buildConditions(sb.where, sb.dialect, &sb.paramCount)
+	if err != nil {
+		return nil, err
 	}
-	whereSQL, whereArgs := buildConditions(sb.where, sb.dialect, &sb.paramCount)
 	query.WriteString(" WHERE ")
 	query.WriteString(whereSQL)
-	return whereArgs
+	return whereArgs, nil
 }
 
 // buildGroupByClause builds the GROUP BY clause and returns its args.
-func (sb *selectBuilder) buildGroupByClause(query *strings.Builder) {
+func (sb *selectBuilder) buildGroupByClause(query *strings.Builder) []any {
 	if len(sb.groupBy) == 0 {
+		return nil
 	}
+	var args []any
 	query.WriteString(" GROUP BY ")
 	for i, col := range sb.groupBy {
 		if i > 0 {
 			query.WriteString(", ")
 		}
-		query.WriteString(col)
+		if len(col.args) > 0 {
+			query.WriteString(bindPlaceholders(col.expr, sb.dialect, &sb.paramCount))
+			args = append(args, col.args...)
+		} else {
+			query.WriteString(col.expr)
+		}
 	}
+	return args
 }
 
 // buildHavingClause builds the HAVING clause and returns its args.
-func (sb *selectBuilder) buildHavingClause(query *strings.Builder) ([]any) {
+func (sb *selectBuilder) buildHavingClause(query *strings.Builder) ([]any, error) {
 	if len(sb.having) == 0 {
-		return nil
+		return nil, nil
+	}
+	havingSQL, havingArgs, err := buildConditions(sb.having, sb.dialect, &sb.paramCount)
+	if err != nil {
+		return nil, err
 	}
-	havingSQL, havingArgs := buildConditions(sb.having, sb.dialect, &sb.paramCount)
 	query.WriteString(" HAVING ")
 	query.WriteString(havingSQL)
-	return havingArgs
+	return havingArgs, nil
+}
+
+// buildQualifyClause builds the QUALIFY clause and returns its args.
+func (sb *selectBuilder) buildQualifyClause(query *strings.Builder) ([]any, error) {
+	if len(sb.qualify) == 0 {
+		return nil, nil
+	}
+	qualifySQL, qualifyArgs, err := buildConditions(sb.qualify, sb.dialect, &sb.paramCount)
+	if err != nil {
+		return nil, err
+	}
+	query.WriteString(" QUALIFY ")
+	query.WriteString(qualifySQL)
+	return qualifyArgs, nil
 }
 
-// buildOrderByClause builds the ORDER BY clause.
-func (sb *selectBuilder) buildOrderByClause(query *strings.Builder) {
+// qualifyWrapper emulates QUALIFY on dialects without it: the query (minus
+// ordering, pagination and locking) becomes a subquery, and the QUALIFY
+// conditions filter its window columns in the outer WHERE. The outer query
+// selects every column of the subquery, so ORDER BY columns must be given
+// by their unqualified output names.
+func (sb *selectBuilder) qualifyWrapper() *selectBuilder {
+	inner := sb.Clone().(*selectBuilder)
+	outer := &selectBuilder{
+		dialect:   sb.dialect,
+		subquery:  &subquery{builder: inner, alias: "qualified"},
+		where:     inner.qualify,
+		orderBy:   inner.orderBy,
+		limit:     inner.limit,
+		percent:   inner.percent,
+		withTies:  inner.withTies,
+		offset:    inner.offset,
+		lockMode:  inner.lockMode,
+		lockWait:  inner.lockWait,
+		forOutput: inner.forOutput,
+		ctes:      inner.ctes,
+		comments:  inner.comments,
+		prefixes:  inner.prefixes,
+		suffixes:  inner.suffixes,
+	}
+	inner.qualify = nil
+	inner.orderBy = nil
+	inner.limit = nil
+	inner.percent = nil
+	inner.withTies = false
+	inner.offset = nil
+	inner.lockMode = ""
+	inner.lockWait = ""
+	inner.forOutput = ""
+	inner.ctes = nil
+	inner.comments = nil
+	inner.prefixes = nil
+	inner.suffixes = nil
+	return outer
+}
+
+// buildOrderByClause builds the ORDER BY clause and returns its args.
+func (sb *selectBuilder) buildOrderByClause(query *strings.Builder) []any {
 	if len(sb.orderBy) == 0 {
-		return
+		return nil
 	}
+	var args []any
 	query.WriteString(" ORDER BY ")
 	for i, ob := range sb.orderBy {
 		if i > 0 {
 			query.WriteString(", ")
 		}
-		query.WriteString(ob.column)
-		query.WriteString(" ")
-		query.WriteString(ob.direction)
+		if ob.raw && len(ob.args) > 0 {
+			query.WriteString(bindPlaceholders(ob.column, sb.dialect, &sb.paramCount))
+			args = append(args, ob.args...)
+			continue
+		}
+		query.WriteString(ob.toSQL(sb.dialect))
+	}
+	return args
+}
+
+// toSQL renders a single ORDER BY item. NULLS FIRST / NULLS LAST is emitted
+// natively where supported and emulated with a CASE sort key elsewhere.
+func (o order) toSQL(dialect Dialect) string {
+	if o.raw {
+		return o.column
+	}
+	item := o.column + collateSQL(dialect, o.collation) + " " + o.direction
+	if o.nulls == "" {
+		return item
+	}
+	switch dialect.(type) {
+	case mysqlDialect, sqlserverDialect:
+		nullRank, valueRank := 1, 0
+		if o.nulls == "FIRST" {
+			nullRank, valueRank = 0, 1
+		}
+		return fmt.Sprintf("CASE WHEN %s IS NULL THEN %d ELSE %d END, %s", o.column, nullRank, valueRank, item)
+	default:
+		return item + " NULLS " + o.nulls
+	}
+}
+
+// buildPaginationClause builds the dialect-specific LIMIT / OFFSET clause and returns its args.
+func (sb *selectBuilder) buildPaginationClause(query *strings.Builder) []any {
+	switch sb.dialect.(type) {
+	case sqlserverDialect, oracleDialect:
+		return sb.buildOffsetFetchClause(query)
+	default:
+		args := sb.buildLimitClause(query)
+		return append(args, sb.buildOffsetClause(query)...)
+	}
+}
+
+// useTop reports whether the limit is expressed as SELECT TOP (n), which SQL
+// Server uses when there is neither an offset nor an ordering to page over,
+// and always for PERCENT and WITH TIES limits.
+func (sb *selectBuilder) useTop() bool {
+	if _, ok := sb.dialect.(sqlserverDialect); !ok {
+		return false
 	}
+	if sb.percent != nil || sb.withTies {
+		return sb.offset == nil
+	}
+	return sb.limit != nil && sb.offset == nil && len(sb.orderBy) == 0
+}
+
+// validateLimit checks that PERCENT and WITH TIES limits are supported by the
+// dialect and can be expressed with the rest of the pagination
+func (sb *selectBuilder) validateLimit() error {
+	if sb.percent == nil && !sb.withTies {
+		return nil
+	}
+	if sb.withTies && sb.limit == nil && sb.percent == nil {
+		return errors.New("LimitWithTies requires Limit or LimitPercent")
+	}
+	if sb.withTies && len(sb.orderBy) == 0 {
+		return errors.New("LimitWithTies requires an ORDER BY")
+	}
+	switch d := sb.dialect.(type) {
+	case sqlserverDialect:
+		if sb.offset != nil {
+			return errors.New("SQL Server does not support PERCENT or WITH TIES limits with an OFFSET")
+		}
+	case oracleDialect:
+		if d.rowNumPagination {
+			return errors.New("PERCENT and WITH TIES limits are not supported with ROWNUM pagination")
+		}
+	default:
+		return errors.New("PERCENT and WITH TIES limits are only supported by SQL Server and Oracle")
+	}
+	return nil
+}
+
+// useRowNum reports whether pagination is emulated with ROWNUM subqueries,
+// as configured on the Oracle dialect for servers older than 12c.
+func (sb *selectBuilder) useRowNum() bool {
+	d, ok := sb.dialect.(oracleDialect)
+	return ok && d.rowNumPagination && (sb.limit != nil || sb.offset != nil)
+}
+
+// buildOffsetFetchClause builds the OFFSET n ROWS FETCH NEXT m ROWS ONLY clause.
+// SQL Server requires an ORDER BY for OFFSET, so an arbitrary ordering is added
+// there when none is set; Oracle omits OFFSET when there is nothing to skip.
+func (sb *selectBuilder) buildOffsetFetchClause(query *strings.Builder) []any {
+	if (sb.limit == nil && sb.percent == nil && sb.offset == nil) || sb.useTop() {
+		return nil
+	}
+	_, isSQLServer := sb.dialect.(sqlserverDialect)
+	var args []any
+	if isSQLServer && len(sb.orderBy) == 0 {
+		query.WriteString(" ORDER BY (SELECT NULL)")
+	}
+	if sb.offset != nil {
+		query.WriteString(" OFFSET ")
+		query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+		query.WriteString(" ROWS")
+		sb.paramCount++
+		args = append(args, *sb.offset)
+	} else if isSQLServer {
+		query.WriteString(" OFFSET 0 ROWS")
+	}
+	if sb.limit != nil || sb.percent != nil {
+		query.WriteString(" FETCH NEXT ")
+		query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+		sb.paramCount++
+		if sb.percent != nil {
+			query.WriteString(" PERCENT")
+			args = append(args, *sb.percent)
+		} else {
+			args = append(args, *sb.limit)
+		}
+		if sb.withTies {
+			query.WriteString(" ROWS WITH TIES")
+		} else {
+			query.WriteString(" ROWS ONLY")
+		}
+	}
+	return args
+}
+
+// buildRowNumWrapper wraps the query body in ROWNUM subqueries to apply the
+// limit and offset. With an offset the result carries an extra rnum__ column.
+func (sb *selectBuilder) buildRowNumWrapper(query *strings.Builder, body string) []any {
+	var args []any
+	if sb.offset == nil {
+		query.WriteString("SELECT * FROM (")
+		query.WriteString(body)
+		query.WriteString(") WHERE ROWNUM <= ")
+		query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+		sb.paramCount++
+		return append(args, *sb.limit)
+	}
+
+	query.WriteString("SELECT * FROM (SELECT q__.*, ROWNUM rnum__ FROM (")
+	query.WriteString(body)
+	query.WriteString(") q__")
+	if sb.limit != nil {
+		query.WriteString(" WHERE ROWNUM <= ")
+		query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+		sb.paramCount++
+		args = append(args, *sb.offset+*sb.limit)
+	}
+	query.WriteString(") WHERE rnum__ > ")
+	query.WriteString(sb.dialect.Placeholder(sb.paramCount))
+	sb.paramCount++
+	return append(args, *sb.offset)
 }
 
 func (sb *selectBuilder) buildLimitClause(query *strings.Builder) []any {
@@ -327,6 +1457,120 @@ func (sb *selectBuilder) buildOffsetClause(query *strings.Builder) []any {
 	return []any{*sb.offset}
 }
 
+// validateLock checks that the requested row locking is supported by the dialect
+func (sb *selectBuilder) validateLock() error {
+	if sb.lockMode == "" {
+		if sb.lockWait != "" {
+			return fmt.Errorf("%s requires ForUpdate or ForShare", sb.lockWait)
+		}
+		return nil
+	}
+	switch sb.dialect.(type) {
+	case sqliteDialect:
+		return errors.New("row locking is not supported by SQLite")
+	case oracleDialect:
+		if sb.lockMode == "SHARE" {
+			return errors.New("FOR SHARE is not supported by Oracle")
+		}
+	case sqlserverDialect:
+		if sb.subquery != nil {
+			return errors.New("row locking hints require a table in the FROM clause on SQL Server")
+		}
+	}
+	return nil
+}
+
+// validateHints checks that the index and table hints are supported by the dialect
+func (sb *selectBuilder) validateHints() error {
+	if err := sb.fromHints.validate(sb.dialect); err != nil {
+		return err
+	}
+	for _, j := range sb.joins {
+		if err := j.hints.validate(sb.dialect); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockHints returns the SQL Server table hint equivalent of the row lock.
+func (sb *selectBuilder) lockHints() []string {
+	if sb.lockMode == "" {
+		return nil
+	}
+	if _, ok := sb.dialect.(sqlserverDialect); !ok {
+		return nil
+	}
+	hints := []string{"ROWLOCK"}
+	if sb.lockMode == "UPDATE" {
+		hints = append(hints, "UPDLOCK")
+	} else {
+		hints = append(hints, "HOLDLOCK")
+	}
+	switch sb.lockWait {
+	case "SKIP LOCKED":
+		hints = append(hints, "READPAST")
+	case "NOWAIT":
+		hints = append(hints, "NOWAIT")
+	}
+	return hints
+}
+
+// ForJSON formats the result as JSON with FOR JSON AUTO or PATH (SQL Server),
+// followed by options such as "ROOT('items')" or "INCLUDE_NULL_VALUES"
+func (sb *selectBuilder) ForJSON(mode string, options ...string) SelectBuilder {
+	return sb.forOutputClause("JSON", mode, []string{"AUTO", "PATH"}, options)
+}
+
+// ForXML formats the result as XML with FOR XML RAW, AUTO, EXPLICIT or PATH
+// (SQL Server), followed by options such as "ROOT('items')" or "ELEMENTS"
+func (sb *selectBuilder) ForXML(mode string, options ...string) SelectBuilder {
+	return sb.forOutputClause("XML", mode, []string{"RAW", "AUTO", "EXPLICIT", "PATH"}, options)
+}
+
+func (sb *selectBuilder) forOutputClause(format, mode string, modes, options []string) SelectBuilder {
+	// XML RAW and PATH accept an element name, e.g. RAW('row'), which keeps its case
+	keyword, element, _ := strings.Cut(strings.TrimSpace(mode), "(")
+	keyword = strings.ToUpper(strings.TrimSpace(keyword))
+	if !slices.Contains(modes, keyword) || (element != "" && (format != "XML" || (keyword != "RAW" && keyword != "PATH"))) {
+		if sb.err == nil {
+			sb.err = fmt.Errorf("invalid FOR %s mode %q", format, mode)
+		}
+		return sb
+	}
+	mode = keyword
+	if element != "" {
+		mode += "(" + element
+	}
+	sb.forOutput = strings.Join(append([]string{format + " " + mode}, options...), ", ")
+	return sb
+}
+
+// buildForOutputClause builds the SQL Server FOR JSON / FOR XML clause
+func (sb *selectBuilder) buildForOutputClause(query *strings.Builder) {
+	if sb.forOutput == "" {
+		return
+	}
+	query.WriteString(" FOR ")
+	query.WriteString(sb.forOutput)
+}
+
+// buildLockClause builds the FOR UPDATE / FOR SHARE clause.
+func (sb *selectBuilder) buildLockClause(query *strings.Builder) {
+	if sb.lockMode == "" {
+		return
+	}
+	if _, ok := sb.dialect.(sqlserverDialect); ok {
+		return
+	}
+	query.WriteString(" FOR ")
+	query.WriteString(sb.lockMode)
+	if sb.lockWait != "" {
+		query.WriteString(" ")
+		query.WriteString(sb.lockWait)
+	}
+}
+
 // subquery implements Subquery
 type subquery struct {
 	builder SQLBuilder
@@ -344,7 +1588,7 @@ func (s *subquery) ToSQL() (string, []any, error) {
 
 // FromSubquery creates a FROM clause with a subquery
 func (sb *selectBuilder) FromSubquery(subq SQLBuilder, alias string) SelectBuilder {
-	sb.table = ""
+	sb.resetFrom()
 	sb.subquery = &subquery{
 		builder: subq,
 		alias:   alias,
@@ -367,6 +1611,28 @@ func (sb *selectBuilder) RightJoinSubquery(subq SQLBuilder, alias, on string) Se
 	return sb.joinSubquery("RIGHT", subq, alias, on)
 }
 
+// LateralJoin adds an INNER JOIN LATERAL with a correlated subquery
+// (CROSS APPLY on SQL Server and Oracle)
+func (sb *selectBuilder) LateralJoin(subq SQLBuilder, alias, on string) SelectBuilder {
+	return sb.lateralJoin("INNER", subq, alias, on)
+}
+
+// LeftLateralJoin adds a LEFT JOIN LATERAL with a correlated subquery
+// (OUTER APPLY on SQL Server and Oracle)
+func (sb *selectBuilder) LeftLateralJoin(subq SQLBuilder, alias, on string) SelectBuilder {
+	return sb.lateralJoin("LEFT", subq, alias, on)
+}
+
+func (sb *selectBuilder) lateralJoin(joinType string, subq SQLBuilder, alias, on string) SelectBuilder {
+	sb.joins = append(sb.joins, join{
+		joinType:  joinType,
+		subquery:  &subquery{builder: subq, alias: alias},
+		condition: on,
+		lateral:   true,
+	})
+	return sb
+}
+
 func (sb *selectBuilder) joinSubquery(joinType string, subq SQLBuilder, alias, on string) SelectBuilder {
 	sb.joins = append(sb.joins, join{
 		joinType:  joinType,
@@ -375,3 +1641,46 @@ func (sb *selectBuilder) joinSubquery(joinType string, subq SQLBuilder, alias, o
 	})
 	return sb
 }
+
+// projectedColumns returns the number of columns a SELECT projects, when it
+// can be determined: SELECT *, t.* and raw expressions listing several
+// columns are not counted.
+func projectedColumns(query SelectBuilder) (int, bool) {
+	sb, ok := query.(*selectBuilder)
+	if !ok || len(sb.columns) == 0 {
+		return 0, false
+	}
+	for _, col := range sb.columns {
+		if col.builder != nil {
+			continue
+		}
+		expr := strings.TrimSpace(col.expr)
+		if expr == "*" || strings.HasSuffix(expr, ".*") || hasTopLevelComma(expr) {
+			return 0, false
+		}
+	}
+	return len(sb.columns), true
+}
+
+// hasTopLevelComma reports whether the expression has a comma outside
+// parentheses and quoted strings
+func hasTopLevelComma(expr string) bool {
+	var (
+		depth   int
+		inQuote bool
+	)
+	for _, r := range expr {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SetStructOption configures SetStruct
+type SetStructOption func(*setStructOptions)
+
+type setStructOptions struct {
+	skipZero bool
+	only     []string
+}
+
+// SkipZero makes SetStruct skip fields holding their zero value, such as
+// nil pointers and empty strings, as typical for PATCH requests
+func SkipZero() SetStructOption {
+	return func(o *setStructOptions) {
+		o.skipZero = true
+	}
+}
+
+// OnlyFields restricts SetStruct to the given fields, named by column or by
+// Go field name
+func OnlyFields(fields ...string) SetStructOption {
+	return func(o *setStructOptions) {
+		o.only = append(o.only, fields...)
+	}
+}
+
+// SetStruct adds a SET clause for each `db` tagged field of a struct (or
+// pointer to struct), in field order:
+//
+//	type PersonPatch struct {
+//		Name  *string `db:"full_name"`
+//		Age   *int    `db:"age"`
+//		Notes string  `db:"-"`
+//	}
+//
+// Non-nil pointers are dereferenced and nil pointers set NULL unless
+// SkipZero is given. Untagged fields and fields tagged "-" are ignored, and
+// embedded structs are walked.
+func (ub *updateBuilder) SetStruct(v any, opts ...SetStructOption) UpdateBuilder {
+	var o setStructOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		if ub.err == nil {
+			ub.err = errors.New("SetStruct requires a struct or a pointer to a struct")
+		}
+		return ub
+	}
+
+	ub.setStructFields(rv, o)
+	return ub
+}
+
+func (ub *updateBuilder) setStructFields(rv reflect.Value, o setStructOptions) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		value := rv.Field(i)
+		tag, tagged := field.Tag.Lookup("db")
+
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			ub.setStructFields(value, o)
+			continue
+		}
+		column, _, _ := strings.Cut(tag, ",")
+		if !tagged || column == "-" || column == "" || !field.IsExported() || !value.CanInterface() {
+			continue
+		}
+		if len(o.only) > 0 && !slices.Contains(o.only, column) && !slices.Contains(o.only, field.Name) {
+			continue
+		}
+		if o.skipZero && value.IsZero() {
+			continue
+		}
+
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				ub.Set(column, nil)
+				continue
+			}
+			value = value.Elem()
+		}
+		ub.Set(column, value.Interface())
+	}
+}
@@ -0,0 +1,53 @@
+package querybuilder
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// SoftDelete turns the delete into an UPDATE setting column to the current
+// time, keeping its WHERE, joins, CTEs and RETURNING, for tables using
+// logical deletion
+func (db *deleteBuilder) SoftDelete(column string) DeleteBuilder {
+	db.softDelete = column
+	return db
+}
+
+// softDeleteUpdate returns the update a soft delete renders as
+func (db *deleteBuilder) softDeleteUpdate() (*updateBuilder, error) {
+	if len(db.targets) > 0 {
+		return nil, errors.New("SoftDelete cannot be combined with DeleteFrom")
+	}
+	for _, j := range db.joins {
+		if j.subquery != nil || len(j.conditions) > 0 {
+			return nil, errors.New("SoftDelete supports only joins with a raw ON clause")
+		}
+	}
+	var from []string
+	for _, u := range db.using {
+		if u.subquery != nil {
+			return nil, errors.New("SoftDelete cannot be combined with UsingSubquery")
+		}
+		from = append(from, u.table)
+	}
+
+	// Update carries the safe mode and updated_at stamping of the builder
+	update := db.update
+	if update == nil {
+		update = New().WithDialect(db.dialect).Update
+	}
+	ub := update(strings.TrimSpace(db.table + " " + db.alias)).(*updateBuilder)
+	ub.from = strings.Join(from, ", ")
+	ub.ctes = cloneCTEs(db.ctes)
+	ub.joins = cloneJoins(db.joins)
+	ub.where = slices.Clone(db.where)
+	ub.orderBy = cloneOrders(db.orderBy)
+	ub.limit = cloneInt(db.limit)
+	ub.returning = slices.Clone(db.returning)
+	ub.comments = slices.Clone(db.comments)
+	ub.prefixes = cloneRawClauses(db.prefixes)
+	ub.suffixes = cloneRawClauses(db.suffixes)
+	ub.SetRaw(db.softDelete, currentTimestampSQL(db.dialect))
+	return ub, nil
+}
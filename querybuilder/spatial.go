@@ -0,0 +1,108 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Geometry is a spatial value given as Well-Known Text with an optional
+// SRID, e.g. Geometry{WKT: "POINT(106.8 -6.2)", SRID: 4326}
+type Geometry struct {
+	WKT  string
+	SRID int
+}
+
+// STWithin creates a condition matching rows whose geometry column lies
+// within geometry. geometry is either a Geometry, which is bound as WKT and
+// converted by the database, or a driver-native value bound as is.
+func STWithin(column string, geometry any) NegatableCondition {
+	return &spatialCondition{function: "Within", column: column, geometry: geometry}
+}
+
+// STIntersects creates a condition matching rows whose geometry column
+// intersects geometry
+func STIntersects(column string, geometry any) NegatableCondition {
+	return &spatialCondition{function: "Intersects", column: column, geometry: geometry}
+}
+
+// STDWithin creates a condition matching rows whose geometry column is
+// within distance of geometry, in the units of the column's spatial
+// reference (meters for SQL Server geography)
+func STDWithin(column string, geometry any, distance float64) NegatableCondition {
+	return &spatialCondition{function: "DWithin", column: column, geometry: geometry, distance: distance}
+}
+
+// spatialCondition handles spatial predicates. PostgreSQL uses the PostGIS
+// ST_* functions, MySQL its spatial functions with ST_Distance standing in
+// for ST_DWithin, and SQL Server the geography methods.
+type spatialCondition struct {
+	function string // "Within", "Intersects", "DWithin"
+	column   string
+	geometry any
+	distance float64
+}
+
+func (c *spatialCondition) validate(dialect Dialect) error {
+	switch dialect.(type) {
+	case postgresDialect, mysqlDialect, sqlserverDialect:
+		return nil
+	default:
+		return errors.New("spatial conditions are only supported by PostgreSQL, MySQL and SQL Server")
+	}
+}
+
+func (c *spatialCondition) ToSQL(dialect Dialect, argPos *int) (string, []any) {
+	geometry, args := c.geometrySQL(dialect, argPos)
+
+	var sql string
+	switch dialect.(type) {
+	case sqlserverDialect:
+		if c.function == "DWithin" {
+			sql = fmt.Sprintf("%s.STDistance(%s) <= %s", c.column, geometry, dialect.Placeholder(*argPos))
+		} else {
+			sql = fmt.Sprintf("%s.ST%s(%s) = 1", c.column, c.function, geometry)
+		}
+	case mysqlDialect:
+		if c.function == "DWithin" {
+			sql = fmt.Sprintf("ST_Distance(%s, %s) <= %s", c.column, geometry, dialect.Placeholder(*argPos))
+		} else {
+			sql = fmt.Sprintf("ST_%s(%s, %s)", c.function, c.column, geometry)
+		}
+	default:
+		if c.function == "DWithin" {
+			sql = fmt.Sprintf("ST_DWithin(%s, %s, %s)", c.column, geometry, dialect.Placeholder(*argPos))
+		} else {
+			sql = fmt.Sprintf("ST_%s(%s, %s)", c.function, c.column, geometry)
+		}
+	}
+	if c.function == "DWithin" {
+		*argPos++
+		args = append(args, c.distance)
+	}
+
+	return sql, args
+}
+
+// geometrySQL renders the geometry argument. WKT is converted with the
+// dialect's text constructor; SQL Server geography requires an SRID and
+// defaults to 4326 (WGS 84).
+func (c *spatialCondition) geometrySQL(dialect Dialect, argPos *int) (string, []any) {
+	placeholder := dialect.Placeholder(*argPos)
+	*argPos++
+
+	g, ok := c.geometry.(Geometry)
+	if !ok {
+		return placeholder, []any{c.geometry}
+	}
+	if _, ok := dialect.(sqlserverDialect); ok {
+		srid := g.SRID
+		if srid == 0 {
+			srid = 4326
+		}
+		return fmt.Sprintf("geography::STGeomFromText(%s, %d)", placeholder, srid), []any{g.WKT}
+	}
+	if g.SRID == 0 {
+		return fmt.Sprintf("ST_GeomFromText(%s)", placeholder), []any{g.WKT}
+	}
+	return fmt.Sprintf("ST_GeomFromText(%s, %d)", placeholder, g.SRID), []any{g.WKT}
+}
@@ -0,0 +1,52 @@
+package querybuilder
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// tableFunction is a set-returning function call such as UNNEST(array),
+// generate_series(1, 10) or STRING_SPLIT(list, ',') used as a relation
+type tableFunction struct {
+	name  string
+	args  []any
+	alias string
+}
+
+// toSQL renders the function call with its arguments bound as placeholders,
+// followed by its alias. Oracle does not accept AS before a table alias.
+func (f *tableFunction) toSQL(dialect Dialect, paramCount *int) (string, []any, error) {
+	if f.name == "" {
+		return "", nil, errors.New("no function specified for FROM clause")
+	}
+
+	var sql strings.Builder
+	sql.WriteString(f.name)
+	sql.WriteString("(")
+	for i := range f.args {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(dialect.Placeholder(*paramCount))
+		*paramCount++
+	}
+	sql.WriteString(")")
+	if f.alias != "" {
+		if _, ok := dialect.(oracleDialect); ok {
+			sql.WriteString(" ")
+		} else {
+			sql.WriteString(" AS ")
+		}
+		sql.WriteString(f.alias)
+	}
+
+	return sql.String(), slices.Clone(f.args), nil
+}
+
+func (f *tableFunction) clone() *tableFunction {
+	if f == nil {
+		return nil
+	}
+	return &tableFunction{name: f.name, args: slices.Clone(f.args), alias: f.alias}
+}
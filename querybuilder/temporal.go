@@ -0,0 +1,53 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// systemTime is a FOR SYSTEM_TIME clause on a system-versioned (temporal)
+// table, either AS OF a point in time or BETWEEN two points in time
+type systemTime struct {
+	asOf bool
+	from time.Time
+	to   time.Time
+}
+
+// validate checks that the dialect supports system-versioned tables
+func (st *systemTime) validate(dialect Dialect) error {
+	switch dialect.(type) {
+	case sqlserverDialect, mysqlDialect:
+		return nil
+	default:
+		return errors.New("FOR SYSTEM_TIME is only supported by SQL Server and MariaDB")
+	}
+}
+
+// toSQL renders the clause to follow the table name. MariaDB needs the
+// TIMESTAMP keyword before each bound point in time.
+func (st *systemTime) toSQL(dialect Dialect, paramCount *int) (string, []any) {
+	prefix := ""
+	if _, ok := dialect.(mysqlDialect); ok {
+		prefix = "TIMESTAMP "
+	}
+
+	var sql strings.Builder
+	if st.asOf {
+		sql.WriteString(" FOR SYSTEM_TIME AS OF ")
+		sql.WriteString(prefix)
+		sql.WriteString(dialect.Placeholder(*paramCount))
+		*paramCount++
+		return sql.String(), []any{st.from}
+	}
+
+	sql.WriteString(" FOR SYSTEM_TIME BETWEEN ")
+	sql.WriteString(prefix)
+	sql.WriteString(dialect.Placeholder(*paramCount))
+	*paramCount++
+	sql.WriteString(" AND ")
+	sql.WriteString(prefix)
+	sql.WriteString(dialect.Placeholder(*paramCount))
+	*paramCount++
+	return sql.String(), []any{st.from, st.to}
+}
@@ -0,0 +1,130 @@
+package querybuilder
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// timestampColumns names the audit columns maintained automatically by
+// inserts and updates
+type timestampColumns struct {
+	createdAt string
+	updatedAt string
+}
+
+// WithTimestamps makes the builder maintain audit columns: inserts set
+// createdAt and updatedAt to the current time and updates set updatedAt,
+// unless the statement already sets them. Either name may be empty.
+func (qb *QueryBuilder) WithTimestamps(createdAt, updatedAt string) Builder {
+	qb.timestamps = &timestampColumns{createdAt: createdAt, updatedAt: updatedAt}
+	return qb
+}
+
+// WithUpdatedAt makes every update of the given tables set column to the
+// current time, unless the statement already sets it. It takes precedence
+// over the updatedAt column of WithTimestamps for those tables.
+func (qb *QueryBuilder) WithUpdatedAt(column string, tables ...string) Builder {
+	if qb.updatedAt == nil {
+		qb.updatedAt = make(map[string]string, len(tables))
+	}
+	for _, table := range tables {
+		qb.updatedAt[strings.ToLower(table)] = column
+	}
+	return qb
+}
+
+// updateTimestamps returns the audit columns maintained by updates of
+// table, which may carry an alias
+func (qb *QueryBuilder) updateTimestamps(table string) *timestampColumns {
+	name, _, _ := strings.Cut(strings.TrimSpace(table), " ")
+	column, ok := qb.updatedAt[strings.ToLower(name)]
+	if !ok {
+		return qb.timestamps
+	}
+	stamps := timestampColumns{updatedAt: column}
+	if qb.timestamps != nil {
+		stamps.createdAt = qb.timestamps.createdAt
+	}
+	return &stamps
+}
+
+// currentTimestampSQL returns the dialect's current timestamp expression
+func currentTimestampSQL(dialect Dialect) string {
+	switch dialect.(type) {
+	case postgresDialect, mysqlDialect:
+		return "NOW()"
+	case sqlserverDialect:
+		return "SYSDATETIME()"
+	case oracleDialect:
+		return "SYSTIMESTAMP"
+	default:
+		return "CURRENT_TIMESTAMP"
+	}
+}
+
+// hasColumn reports whether the column is in the list, ignoring case
+func hasColumn(columns []string, column string) bool {
+	return slices.ContainsFunc(columns, func(c string) bool {
+		return strings.EqualFold(c, column)
+	})
+}
+
+// withTimestamps returns a copy of the insert with the audit columns added
+// to every row, or nil when there is nothing to add. Only VALUES inserts
+// with explicit columns are stamped; an upsert also refreshes updatedAt.
+func (ib *insertBuilder) withTimestamps() *insertBuilder {
+	if ib.timestamps == nil || len(ib.columns) == 0 || len(ib.values) == 0 {
+		return nil
+	}
+
+	now := rawSQL{value: currentTimestampSQL(ib.dialect), safe: true}
+	stamped := ib.Clone().(*insertBuilder)
+	stamped.timestamps = nil
+
+	for _, col := range []string{ib.timestamps.createdAt, ib.timestamps.updatedAt} {
+		if col == "" || hasColumn(stamped.columns, col) {
+			continue
+		}
+		stamped.columns = append(stamped.columns, col)
+		for i := range stamped.values {
+			stamped.values[i] = append(stamped.values[i], now)
+		}
+	}
+
+	if c := stamped.conflict; c != nil && !c.DoNothing && len(c.DoUpdate) > 0 && ib.timestamps.updatedAt != "" &&
+		!hasColumn(slices.Collect(maps.Keys(c.DoUpdate)), ib.timestamps.updatedAt) {
+		c.DoUpdate[ib.timestamps.updatedAt] = now
+	}
+	return stamped
+}
+
+// setsWithTimestamps returns the SET clauses with updatedAt appended when
+// the builder maintains it and the update doesn't already set it. A
+// multi-table MySQL update qualifies it with the updated table's alias.
+func (ub *updateBuilder) setsWithTimestamps() []setClause {
+	if ub.timestamps == nil || ub.timestamps.updatedAt == "" {
+		return ub.sets
+	}
+	for _, set := range ub.sets {
+		column := set.column
+		if i := strings.LastIndex(column, "."); i >= 0 {
+			column = column[i+1:]
+		}
+		if strings.EqualFold(column, ub.timestamps.updatedAt) {
+			return ub.sets
+		}
+	}
+
+	column := ub.timestamps.updatedAt
+	if _, ok := ub.dialect.(mysqlDialect); ok && (ub.from != "" || len(ub.joins) > 0) {
+		if fields := strings.Fields(ub.table); len(fields) > 0 {
+			column = fields[len(fields)-1] + "." + column
+		}
+	}
+	return append(slices.Clone(ub.sets), setClause{
+		column: column,
+		value:  currentTimestampSQL(ub.dialect),
+		isRaw:  true,
+	})
+}
@@ -0,0 +1,201 @@
+package querybuilder
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// TreeQuery interface for walking a self-referencing table from its root
+// rows down to their descendants
+type TreeQuery interface {
+	Columns(columns ...string) TreeQuery
+	StartWith(conditions ...Condition) TreeQuery
+	MaxDepth(depth int) TreeQuery
+	As(name string) TreeQuery
+	ToSQL() (string, []any, error)
+}
+
+// treeQuery implements TreeQuery. It renders a recursive CTE on most
+// dialects and START WITH / CONNECT BY PRIOR on Oracle.
+type treeQuery struct {
+	dialect      Dialect
+	table        string
+	idColumn     string
+	parentColumn string
+	columns      []string
+	root         []Condition
+	maxDepth     *int
+	name         string
+}
+
+// Tree begins a hierarchical query over table, where parentColumn of each
+// row references idColumn of its parent
+func (qb *QueryBuilder) Tree(table, idColumn, parentColumn string) TreeQuery {
+	return &treeQuery{
+		dialect:      qb.dialect,
+		table:        table,
+		idColumn:     idColumn,
+		parentColumn: parentColumn,
+		name:         "tree",
+	}
+}
+
+// Columns sets the columns to select besides the id and parent columns
+func (tq *treeQuery) Columns(columns ...string) TreeQuery {
+	tq.columns = append(tq.columns, columns...)
+	return tq
+}
+
+// StartWith sets the conditions selecting the root rows
+func (tq *treeQuery) StartWith(conditions ...Condition) TreeQuery {
+	tq.root = append(tq.root, conditions...)
+	return tq
+}
+
+// MaxDepth limits the walk to the given number of levels, roots being level 1
+func (tq *treeQuery) MaxDepth(depth int) TreeQuery {
+	tq.maxDepth = &depth
+	return tq
+}
+
+// As sets the name of the recursive CTE (default "tree")
+func (tq *treeQuery) As(name string) TreeQuery {
+	tq.name = name
+	return tq
+}
+
+// selectColumns returns the selected columns, making sure the id and parent
+// columns are present so the recursive step can join on them
+func (tq *treeQuery) selectColumns() []string {
+	columns := []string{}
+	for _, col := range []string{tq.idColumn, tq.parentColumn} {
+		if !slices.Contains(tq.columns, col) {
+			columns = append(columns, col)
+		}
+	}
+	return append(columns, tq.columns...)
+}
+
+// ToSQL generates the SQL query and returns the query and parameters.
+// Every row carries its level in a depth column, roots being 1.
+func (tq *treeQuery) ToSQL() (string, []any, error) {
+	if tq.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+	if tq.idColumn == "" || tq.parentColumn == "" {
+		return "", nil, errors.New("tree query requires id and parent columns")
+	}
+	if len(tq.root) == 0 {
+		return "", nil, errors.New("tree query requires a root condition")
+	}
+	if tq.maxDepth != nil && *tq.maxDepth < 1 {
+		return "", nil, errors.New("max depth must be at least 1")
+	}
+
+	if _, ok := tq.dialect.(oracleDialect); ok {
+		return tq.connectBySQL()
+	}
+	return tq.recursiveSQL()
+}
+
+// recursiveSQL renders the walk as a recursive CTE
+func (tq *treeQuery) recursiveSQL() (string, []any, error) {
+	var (
+		query      strings.Builder
+		args       []any
+		paramCount int
+	)
+	columns := tq.selectColumns()
+
+	query.WriteString("WITH ")
+	switch tq.dialect.(type) {
+	case postgresDialect, mysqlDialect, sqliteDialect:
+		query.WriteString("RECURSIVE ")
+	}
+	query.WriteString(tq.name)
+	query.WriteString(" AS (")
+
+	// Anchor member: the root rows
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(columns, ", "))
+	query.WriteString(", 1 AS depth FROM ")
+	query.WriteString(escapeTableRef(tq.dialect, tq.table))
+	rootSQL, rootArgs, err := buildConditions(tq.root, tq.dialect, &paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	query.WriteString(" WHERE ")
+	query.WriteString(rootSQL)
+	args = append(args, rootArgs...)
+
+	// Recursive member: children of the rows found so far
+	query.WriteString(" UNION ALL SELECT ")
+	for i, col := range columns {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("t.")
+		query.WriteString(col)
+	}
+	query.WriteString(", ")
+	query.WriteString(tq.name)
+	query.WriteString(".depth + 1 FROM ")
+	query.WriteString(escapeTableRef(tq.dialect, tq.table))
+	query.WriteString(" t JOIN ")
+	query.WriteString(tq.name)
+	query.WriteString(" ON t.")
+	query.WriteString(tq.parentColumn)
+	query.WriteString(" = ")
+	query.WriteString(tq.name)
+	query.WriteString(".")
+	query.WriteString(tq.idColumn)
+	if tq.maxDepth != nil {
+		query.WriteString(" WHERE ")
+		query.WriteString(tq.name)
+		query.WriteString(".depth < ")
+		query.WriteString(tq.dialect.Placeholder(paramCount))
+		args = append(args, *tq.maxDepth)
+	}
+	query.WriteString(")")
+
+	query.WriteString(" SELECT ")
+	query.WriteString(strings.Join(columns, ", "))
+	query.WriteString(", depth FROM ")
+	query.WriteString(tq.name)
+
+	return query.String(), args, nil
+}
+
+// connectBySQL renders the walk with Oracle's hierarchical query clauses
+func (tq *treeQuery) connectBySQL() (string, []any, error) {
+	var (
+		query      strings.Builder
+		args       []any
+		paramCount int
+	)
+
+	query.WriteString("SELECT ")
+	query.WriteString(strings.Join(tq.selectColumns(), ", "))
+	query.WriteString(", LEVEL AS depth FROM ")
+	query.WriteString(escapeTableRef(tq.dialect, tq.table))
+	rootSQL, rootArgs, err := buildConditions(tq.root, tq.dialect, &paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	query.WriteString(" START WITH ")
+	query.WriteString(rootSQL)
+	args = append(args, rootArgs...)
+
+	query.WriteString(" CONNECT BY PRIOR ")
+	query.WriteString(tq.idColumn)
+	query.WriteString(" = ")
+	query.WriteString(tq.parentColumn)
+	if tq.maxDepth != nil {
+		query.WriteString(" AND LEVEL <= ")
+		query.WriteString(tq.dialect.Placeholder(paramCount))
+		args = append(args, *tq.maxDepth)
+	}
+
+	return query.String(), args, nil
+}
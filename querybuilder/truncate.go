@@ -0,0 +1,82 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// TruncateBuilder builds a statement emptying a table
+type TruncateBuilder interface {
+	Cascade() TruncateBuilder
+	RestartIdentity() TruncateBuilder
+	ToSQL() (string, []any, error)
+}
+
+// truncateBuilder implements TruncateBuilder
+type truncateBuilder struct {
+	dialect         Dialect
+	table           string
+	cascade         bool
+	restartIdentity bool
+}
+
+// Truncate begins a TRUNCATE TABLE statement. SQLite has no TRUNCATE and
+// gets DELETE FROM instead.
+func (qb *QueryBuilder) Truncate(table string) TruncateBuilder {
+	return &truncateBuilder{
+		dialect: qb.dialect,
+		table:   table,
+	}
+}
+
+// Cascade also truncates the tables referencing this one through foreign
+// keys. Supported by PostgreSQL and Oracle.
+func (tb *truncateBuilder) Cascade() TruncateBuilder {
+	tb.cascade = true
+	return tb
+}
+
+// RestartIdentity resets the table's identity columns. PostgreSQL restarts
+// them explicitly; MySQL and SQL Server always do on TRUNCATE.
+func (tb *truncateBuilder) RestartIdentity() TruncateBuilder {
+	tb.restartIdentity = true
+	return tb
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (tb *truncateBuilder) ToSQL() (string, []any, error) {
+	if tb.table == "" {
+		return "", nil, errors.New("no table specified")
+	}
+
+	var query strings.Builder
+	switch tb.dialect.(type) {
+	case postgresDialect:
+		query.WriteString("TRUNCATE TABLE " + escapeTableRef(tb.dialect, tb.table))
+		if tb.restartIdentity {
+			query.WriteString(" RESTART IDENTITY")
+		}
+		if tb.cascade {
+			query.WriteString(" CASCADE")
+		}
+	case mysqlDialect, sqlserverDialect:
+		if tb.cascade {
+			return "", nil, errors.New("TRUNCATE ... CASCADE is only supported by PostgreSQL and Oracle")
+		}
+		query.WriteString("TRUNCATE TABLE " + escapeTableRef(tb.dialect, tb.table))
+	case oracleDialect:
+		if tb.restartIdentity {
+			return "", nil, errors.New("TRUNCATE ... RESTART IDENTITY is not supported by Oracle")
+		}
+		query.WriteString("TRUNCATE TABLE " + escapeTableRef(tb.dialect, tb.table))
+		if tb.cascade {
+			query.WriteString(" CASCADE")
+		}
+	default:
+		if tb.cascade || tb.restartIdentity {
+			return "", nil, errors.New("SQLite has no TRUNCATE, so CASCADE and RESTART IDENTITY are not supported")
+		}
+		query.WriteString("DELETE FROM " + escapeTableRef(tb.dialect, tb.table))
+	}
+	return query.String(), nil, nil
+}
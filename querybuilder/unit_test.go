@@ -1,14 +1,24 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestSelect(t *testing.T) {
 	tests := []struct {
-		name    string
+		name     string
 		sb 		SelectBuilder
-		isError bool
+		expected string
+		isError  bool
 	}{
 		{
 			name: "Select Basic MySQL",
@@ -60,6 +70,8 @@ func TestSelect(t *testing.T) {
 				Where(LtOrEq("p.age", 20)).
 				OrderBy("p.age", "ASC").
 				Limit(10).Offset(10),
+			expected: "SELECT p.id, p.full_name, p.age, o.order_id FROM people p LEFT JOIN orders o ON p.id = o.person_id " +
+				"WHERE p.age <= :1 ORDER BY p.age ASC OFFSET :2 ROWS FETCH NEXT :3 ROWS ONLY",
 		},
 		{
 			name: "Select Basic with Having Clause SQLite",
@@ -115,122 +127,2870 @@ func TestSelect(t *testing.T) {
 				Limit(10).Offset(10),
 		},
 		{
-			name: "Select with table is nil MySQL",
-			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").From("").Where(Gt("age", 10)),
+			name: "Select with CTE Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("a.id", "a.full_name").
+				With("adults", New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").Where(Gt("age", 17))).
+				From("adults a").
+				Where(Like("a.full_name", "%arif")),
+		},
+		{
+			name: "Select with Recursive CTE MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id", "parent_id").
+				WithRecursive("tree", New().WithDialect(NewMySQLDialect()).Select("id", "parent_id").From("categories").Where(Eq("id", 1))).
+				From("tree"),
+		},
+		{
+			name: "Select Raw Expression SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").
+				SelectRaw("COALESCE(score, ?) AS score", 0).
+				From("people").
+				Where(Gt("age", 10)),
+		},
+		{
+			name: "Select with Window Function Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "dept", "salary").
+				SelectWindow(RowNumber().PartitionBy("dept").OrderBy("salary", "DESC").As("rn")).
+				SelectWindow(Window("SUM(salary)").PartitionBy("dept").OrderBy("hired_at", "ASC").Rows(UnboundedPreceding, CurrentRow).As("running_total")).
+				From("employees"),
+		},
+		{
+			name: "Select with Window Frame without Order MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").
+				SelectWindow(Window("AVG(salary)").Rows(Preceding(2), Following(2))).
+				From("employees"),
 			isError: true,
 		},
 		{
-			name: "Select with table in subquery is nil MySQL",
-			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").FromSubquery(&subquery{
-				builder: New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").From("people").Where(Gt("age", 10)),
-			}, "p").JoinSubquery(&subquery{
-				builder: New().WithDialect(NewMySQLDialect()).Select("order_id", "person_id"),
-			}, "o", "p.id = o.person_id"),
+			name: "Select For Update Skip Locked Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("jobs").
+				Where(Eq("status", "pending")).Limit(10).ForUpdate().SkipLocked(),
+		},
+		{
+			name: "Select For Update No Wait SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("jobs").
+				Where(Eq("status", "pending")).ForUpdate().NoWait(),
+		},
+		{
+			name: "Select For Share SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("jobs").ForShare(),
 			isError: true,
 		},
 		{
-			name: "Select with table in subquery FRPM is nil MySQL",
-			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").FromSubquery(&subquery{
-				builder: New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").Where(Gt("age", 10)),
-			}, "p"),
+			name: "Select Order By Nulls Last Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "last_login").From("people").
+				OrderByNulls("last_login", "DESC", "LAST"),
+		},
+		{
+			name: "Select Order By Nulls First MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id", "last_login").From("people").
+				OrderByNulls("last_login", "ASC", "FIRST").OrderBy("id", "ASC"),
+		},
+		{
+			name: "Select Order By Raw MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id", "status").From("tickets").
+				OrderByRaw("FIELD(status, ?, ?, ?)", "new", "open", "closed").Limit(10),
+		},
+		{
+			name: "Select Order By Raw Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("tickets").Where(Eq("owner_id", 7)).
+				OrderByRaw("created_at::date DESC").OrderByRaw("priority = ? DESC", "high"),
+		},
+		{
+			name: "Select Top SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").From("people").
+				Where(Gt("age", 10)).Limit(10),
+		},
+		{
+			name: "Select Offset Fetch SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").From("people").
+				Where(Gt("age", 10)).OrderBy("id", "ASC").Limit(10).Offset(20),
+		},
+		{
+			name: "Select Offset without Order SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").From("people").Offset(20),
+		},
+		{
+			name: "Select Offset Fetch Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id", "full_name").From("people").
+				Where(Gt("age", 10)).OrderBy("id", "ASC").Limit(10).Offset(20),
+		},
+		{
+			name: "Select RowNum Pagination Oracle",
+			sb: New().WithDialect(NewOracleDialect(WithRowNumPagination())).Select("id", "full_name").From("people").
+				Where(Gt("age", 10)).OrderBy("id", "ASC").Limit(10).Offset(20),
+		},
+		{
+			name: "Select RowNum Limit Oracle",
+			sb: New().WithDialect(NewOracleDialect(WithRowNumPagination())).Select("id", "full_name").From("people").
+				Limit(10),
+		},
+		{
+			name: "Select with Join On Conditions Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("p.id", "o.order_id").
+				From("people p").
+				JoinOn("orders o", ColumnEq("p.id", "o.person_id"), Eq("o.status", "paid")).
+				LeftJoinOn("public.refunds r", ColumnEq("r.order_id", "o.order_id")).
+				Where(Gt("p.age", 10)),
+		},
+		{
+			name: "Select with Join On Conditions Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("p.id").
+				From("people p").
+				JoinOn("orders o", ColumnEq("p.id", "o.pid"), Eq("o.status", "paid")),
+			expected: "SELECT p.id FROM people p INNER JOIN orders o ON p.id = o.pid AND o.status = :1",
+		},
+		{
+			name: "Select with Join On Conditions SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("p.id", "o.order_id").
+				From("people p").
+				JoinOn("orders AS o", ColumnEq("p.id", "o.person_id"), Eq("o.status", "paid")).
+				Where(Gt("p.age", 10)),
+		},
+		{
+			name: "Select with Join On Expression Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("a.id").
+				From("accounts a").
+				JoinOn("backups b", ColumnEq("LOWER(a.email)", "b.email"), ColumnGt("a.updated_at", "b.copied_at")),
+			expected: `SELECT a.id FROM "accounts" "a" INNER JOIN "backups" "b" ON LOWER(a.email) = "b"."email" AND "a"."updated_at" > "b"."copied_at"`,
+		},
+		{
+			name: "Select with Join Using MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("person_id", "o.order_id").
+				From("people_details d").
+				JoinUsing("orders o", "person_id").
+				LeftJoinUsing("shipments", "person_id", "order_id"),
+		},
+		{
+			name: "Select with Join Using SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("person_id").
+				From("people_details").
+				JoinUsing("orders", "person_id"),
 			isError: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			query, args, err := tt.sb.ToSQL()
-			if tt.isError && err == nil {
-				t.Error("should return error")
-			} else {
-				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
-			}
-		})
-	}
-}
-
-func TestInsertSingleBasic(t *testing.T) {
-	tests := []struct {
-		name    string
-		ib 	InsertBuilder
-		isError bool
-	}{
 		{
-			name: "Insert MySQL",
-			ib:   New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full name", "age", "is_healthy").Values(1, "Arif", 10, false), 
+			name: "Select with Lateral Join Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("p.id", "lo.order_id").
+				From("people p").
+				LeftLateralJoin(New().WithDialect(NewPostgreSQLDialect()).Select("order_id").From("orders o").
+					Where(ColumnEq("o.person_id", "p.id"), Eq("o.status", "paid")).
+					OrderBy("o.created_at", "DESC").Limit(1), "lo", "").
+				Where(Gt("p.age", 10)),
 		},
 		{
-			name: "Insert Postgress",
-			ib:   New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full name", "age", "is_healthy").Values(1, "Arif", 10, false),
+			name: "Select with Cross Apply SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("p.id", "lo.order_id").
+				From("people p").
+				LateralJoin(New().WithDialect(NewSQLServerDialect()).Select("order_id").From("orders o").
+					Where(ColumnEq("o.person_id", "p.id")).Limit(1), "lo", "").
+				Where(Gt("p.age", 10)),
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			query, args, err := tt.ib.ToSQL()
-			if tt.isError && err == nil {
-				t.Error("Should return error")
-			} else {
-				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
-			}
-		})
-	}
-}
-
-func TestUpdateBasic(t *testing.T) {
-	tests := []struct {
-		name    string
-		ub 	UpdateBuilder
-		isError bool
-	}{
 		{
-			name: "Update MySQL",
-			ub:   New().WithDialect(NewMySQLDialect()).Update("people").SetValues(map[string]any{
-				"fullname":   "Arif Setiawan",
-				"occupation": "Software Engineer",
-			}).Where(Eq("id", 1)),
+			name: "Select with Table Alias MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("p.id", "o.order_id").
+				From("people").As("p").
+				JoinOn("orders o", ColumnEq("p.id", "o.person_id")).
+				Where(Gt("p.age", 10)),
 		},
 		{
-			name: "Update Postgress",
-			ub:   New().WithDialect(NewPostgreSQLDialect()).Update("people").SetValues(map[string]any{
-				"fullname":   "Arif Setiawan",
-				"occupation": "Software Engineer",
-			}).Where(Eq("id", 1)),
+			name: "Select with From As Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("p.id").
+				FromAs("hr.people", "p").
+				Where(Gt("p.age", 10)),
+			expected: "SELECT p.id FROM hr.people p WHERE p.age > :1",
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			query, args, err := tt.ub.ToSQL()
-			if tt.isError && err == nil {
-				t.Error("Should return error")
-			} else {
-				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
-			}
-		})
-	}
-}
-
-func TestDeleteBasic(t *testing.T) {
-	tests := []struct {
-		name    string
-		db DeleteBuilder
-		isError bool
-	}{
 		{
-			name: "Delete MySQL",
-			db:   New().WithDialect(NewMySQLDialect()).Delete("people").Where(Eq("id", 1)),
+			name: "Select from Many Tables SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("p.id", "o.order_id").
+				FromMany("people p", "orders o").
+				Where(ColumnEq("p.id", "o.person_id"), Gt("p.age", 10)),
 		},
 		{
-			name: "Delete Postgress",
-			db:   New().WithDialect(NewPostgreSQLDialect()).Delete("people").Where(Eq("id", 1)),
+			name: "Select Where Exists Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("p.id").From("people p").
+				Where(Gt("p.age", 10)).
+				WhereExists(New().WithDialect(NewPostgreSQLDialect()).Select("1").From("orders o").
+					Where(ColumnEq("o.person_id", "p.id"), Eq("o.status", "paid"))).
+				Limit(10),
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			query, args, err := tt.db.ToSQL()
-			if tt.isError && err == nil {
-				t.Error("Should return error")
-			} else {
-				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
-			}
-		})
+		{
+			name: "Select Where Exists with String Literal Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("p.id").From("people p").
+				Where(Eq("p.status", "active")).
+				WhereExists(New().WithDialect(NewOracleDialect()).Select("1").From("logs l").
+					Where(Expr("l.ts = '10:1'"), Eq("l.kind", "login"))),
+			expected: "SELECT p.id FROM people p WHERE p.status = :1 AND EXISTS (SELECT 1 FROM logs l WHERE (l.ts = '10:1') AND l.kind = :2)",
+		},
+		{
+			name: "Select Where Exists with String Literal Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("p.id").From("people p").
+				Where(Eq("p.status", "active")).
+				WhereExists(New().WithDialect(NewPostgreSQLDialect()).Select("1").From("logs l").
+					Where(Expr("l.note = 'it''s $1'"), Eq("l.kind", "login"))),
+			expected: `SELECT p.id FROM "people" "p" WHERE p.status = $1 AND EXISTS (SELECT 1 FROM "logs" "l" WHERE (l.note = 'it''s $1') AND l.kind = $2)`,
+		},
+		{
+			name: "Select Where Not Exists SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("p.id").From("people p").
+				Where(Or(Eq("p.status", "active"), NotExists(New().WithDialect(NewSQLServerDialect()).Select("1").From("bans b").
+					Where(ColumnEq("b.person_id", "p.id"), Gt("b.expires_at", "2024-01-01"))))),
+		},
+		{
+			name: "Select with Aggregates Postgress",
+			sb: func() SelectBuilder {
+				qb := New().WithDialect(NewPostgreSQLDialect())
+				orders := qb.Count("o.order_id")
+				return qb.Select("p.id").
+					SelectExpr(orders.As("order_count"), qb.Sum("o.amount").As("total_amount")).
+					From("people p").
+					JoinOn("orders o", ColumnEq("p.id", "o.person_id")).
+					GroupBy("p.id").
+					Having(orders.Gt(5))
+			}(),
+		},
+		{
+			name: "Select with Aggregates Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("p.id").
+				SelectExpr(New().WithDialect(NewOracleDialect()).Count("o.order_id").As("order_count")).
+				From("people p").
+				JoinOn("orders o", ColumnEq("p.id", "o.person_id")).
+				GroupBy("p.id"),
+			expected: "SELECT p.id, COUNT(o.order_id) AS order_count FROM people p INNER JOIN orders o ON p.id = o.person_id GROUP BY p.id",
+		},
+		{
+			name: "Select with Aggregate Expression Postgress",
+			sb: func() SelectBuilder {
+				qb := New().WithDialect(NewPostgreSQLDialect())
+				return qb.Select("o.customer_id").
+					SelectExpr(qb.Sum("price * qty").As("total"), qb.Count("o.id").As("order count")).
+					From("orders o").
+					GroupBy("o.customer_id")
+			}(),
+			expected: `SELECT o.customer_id, SUM(price * qty) AS "total", COUNT("o"."id") AS "order count" FROM "orders" "o" GROUP BY o.customer_id`,
+		},
+		{
+			name: "Select with Count Distinct MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select().
+				SelectExpr(New().WithDialect(NewMySQLDialect()).CountDistinct("person_id").As("buyers")).
+				From("orders"),
+		},
+		{
+			name: "Select Distinct On Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("person_id", "order_id", "created_at").From("orders").
+				DistinctOn("person_id").OrderBy("person_id", "ASC").OrderBy("created_at", "DESC"),
+		},
+		{
+			name: "Select Distinct On MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("person_id").From("orders").DistinctOn("person_id"),
+			isError: true,
+		},
+		{
+			name: "Select with Conditional Chaining MySQL",
+			sb: func() SelectBuilder {
+				name, minAge, page := "arif", 0, 2
+				return New().WithDialect(NewMySQLDialect()).Select("id", "full_name").From("people").
+					WhereIf(name != "", Like("full_name", "%"+name+"%")).
+					WhereIf(minAge > 0, GtOrEq("age", minAge)).
+					OrderByIf(page > 0, "id", "ASC").
+					LimitIf(page > 0, 20).
+					OffsetIf(page > 1, (page-1)*20)
+			}(),
+		},
+		{
+			name: "Select Seek After Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "created_at").From("posts").
+				Where(Eq("author_id", 7)).
+				SeekAfter([]string{"created_at", "id"}, []any{"2024-01-01 10:00:00", 42}).
+				Limit(20),
+		},
+		{
+			name: "Select Seek Before SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "created_at").From("posts").
+				SeekBefore([]string{"created_at", "id"}, []any{"2024-01-01 10:00:00", 42}).
+				Limit(20),
+		},
+		{
+			name: "Select Seek with Mismatched Values MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("posts").
+				SeekAfter([]string{"created_at", "id"}, []any{42}),
+			isError: true,
+		},
+		{
+			name: "Select with Index Hints MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("p.id", "o.order_id").
+				From("people p").ForceIndex("idx_people_age").
+				Join("orders o", "p.id = o.person_id").UseIndex("idx_orders_person").
+				Where(Gt("p.age", 10)),
+		},
+		{
+			name: "Select with Table Hints SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("p.id", "o.order_id").
+				From("people p").WithTableHint("NOLOCK").
+				Join("orders o", "p.id = o.person_id").WithTableHint("NOLOCK").
+				Where(Gt("p.age", 10)),
+		},
+		{
+			name: "Select with Index Hints Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").UseIndex("idx_people_age"),
+			isError: true,
+		},
+		{
+			name: "Select with Comment and Hint Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("people p").
+				Comment("service=billing route=/people */ DROP TABLE people").
+				Hint("INDEX(p idx_people_age)").
+				Where(Gt("p.age", 10)),
+		},
+		{
+			name: "Select Into SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").Into("people_archive").
+				From("people").Where(Lt("created_at", "2020-01-01")),
+		},
+		{
+			name: "Select Into MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").Into("people_archive").From("people"),
+			isError: true,
+		},
+		{
+			name: "Select Group By Raw Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select().
+				SelectRaw("date_trunc(?, created_at) AS bucket", "day").
+				SelectExpr(New().WithDialect(NewPostgreSQLDialect()).Count("*").As("total")).
+				From("orders").
+				Where(Eq("status", "paid")).
+				GroupByRaw("date_trunc(?, created_at)", "day"),
+		},
+		{
+			name: "Select Group By Raw MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("DATE(created_at) AS day", "COUNT(*) AS total").
+				From("orders").GroupByRaw("DATE(created_at)"),
+		},
+		{
+			name: "Select with Prefix and Suffix Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Prefix("SET LOCAL statement_timeout = 5000;").
+				Where(Gt("age", 10)).
+				Suffix("FETCH FIRST ? ROWS ONLY", 5),
+		},
+		{
+			name: "Select from Values Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("v.sku", "v.qty").
+				FromValues([][]any{{"A-1", 2}, {"B-7", 5}}, "v", "sku", "qty").
+				Where(Gt("v.qty", 1)),
+		},
+		{
+			name: "Select Join Values MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("p.id", "v.qty").From("products p").
+				JoinValues([][]any{{"A-1", 2}, {"B-7", 5}}, "v", "v.sku = p.sku", "sku", "qty"),
+		},
+		{
+			name: "Select from Values Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("v.sku", "v.qty").
+				FromValues([][]any{{"A-1", 2}, {"B-7", 5}}, "v", "sku", "qty"),
+		},
+		{
+			name: "Select from Values with Mismatched Row SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("v.sku").
+				FromValues([][]any{{"A-1", 2}, {"B-7"}}, "v", "sku", "qty"),
+			isError: true,
+		},
+		{
+			name: "Select from Table replacing Values Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").
+				FromValues([][]any{{1}}, "v", "id").From("people"),
+			expected: `SELECT id FROM "people"`,
+		},
+		{
+			name:     "Select From Mixed Case Table Postgress",
+			sb:       New().WithDialect(NewPostgreSQLDialect()).Select("id").From("People"),
+			expected: `SELECT id FROM "People"`,
+		},
+		{
+			name:     "Select From Mixed Case Table with Alias Postgress",
+			sb:       New().WithDialect(NewPostgreSQLDialect()).Select("p.id").From("People").As("p"),
+			expected: `SELECT p.id FROM "People" "p"`,
+		},
+		{
+			name: "Select from Values with As Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("w.id").
+				FromValues([][]any{{1}, {2}}, "v", "id").As("w"),
+			expected: "SELECT w.id FROM (VALUES ($1), ($2)) AS w(id)",
+		},
+		{
+			name: "Select with Qualify Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "dept", "salary").
+				SelectWindow(RowNumber().PartitionBy("dept").OrderBy("salary", "DESC").As("rn")).
+				From("employees").
+				Where(Eq("active", true)).
+				Qualify(LtOrEq("rn", 3)).
+				OrderBy("dept", "ASC").Limit(50),
+		},
+		{
+			name: "Select with Qualify Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id", "dept").
+				SelectWindow(RowNumber().PartitionBy("dept").OrderBy("salary", "DESC").As("rn")).
+				From("employees").
+				Qualify(Eq("rn", 1)),
+			expected: "SELECT * FROM (SELECT id, dept, ROW_NUMBER() OVER (PARTITION BY dept ORDER BY salary DESC) AS rn FROM employees) qualified WHERE rn = :1",
+		},
+		{
+			name: "Select with Limit With Ties SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "score").From("results").
+				OrderBy("score", "DESC").Limit(3).LimitWithTies(),
+		},
+		{
+			name: "Select with Limit Percent SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("results").
+				OrderBy("score", "DESC").LimitPercent(10),
+		},
+		{
+			name: "Select with Limit Percent With Ties Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id", "score").From("results").
+				OrderBy("score", "DESC").Offset(5).LimitPercent(10).LimitWithTies(),
+		},
+		{
+			name: "Select with Limit With Ties without Order By Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("results").
+				Limit(3).LimitWithTies(),
+			isError: true,
+		},
+		{
+			name: "Select with Limit With Ties Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("results").
+				OrderBy("score", "DESC").Limit(3).LimitWithTies(),
+			isError: true,
+		},
+		{
+			name: "Select from Function Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("n").
+				FromFunction("generate_series", 1, 10).As("n").
+				Where(Gt("n", 3)),
+		},
+		{
+			name: "Select from Function SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("s.value").
+				FromFunction("STRING_SPLIT", "a,b,c", ",").As("s").
+				Join("tags t", "t.name = s.value").
+				Where(Eq("t.active", true)),
+		},
+		{
+			name: "Select As Of SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "salary").
+				FromAs("employees", "e").
+				AsOf(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+				Where(Eq("e.dept", "sales")),
+		},
+		{
+			name: "Select for System Time Between MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id", "salary").From("employees").
+				ForSystemTimeBetween(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)).
+				Where(Eq("id", 7)),
+		},
+		{
+			name: "Select As Of Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("employees").
+				AsOf(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			isError: true,
+		},
+		{
+			name: "Select for JSON Path SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name AS [person.name]").From("people").
+				Where(Gt("age", 10)).OrderBy("id", "ASC").Limit(10).
+				ForJSON("path", "ROOT('people')", "INCLUDE_NULL_VALUES"),
+		},
+		{
+			name: "Select for XML Raw SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").From("people").
+				ForXML("RAW('person')", "ROOT('people')", "ELEMENTS"),
+		},
+		{
+			name: "Select for JSON with Invalid Mode SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("people").
+				ForJSON("RAW"),
+			isError: true,
+		},
+		{
+			name: "Select for JSON MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("people").
+				ForJSON("AUTO"),
+			isError: true,
+		},
+		{
+			name: "Select with Collate Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "name").From("people").
+				Where(Collate(Eq("name", "müller"), "de_DE")).
+				OrderByCollate("name", "ASC", "de_DE"),
+		},
+		{
+			name: "Select with Collate SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id", "name").From("people").
+				Where(Collate(Or(Eq("name", "bob"), Like("nickname", "bo%")), "NOCASE"), Gt("age", 10)).
+				OrderByCollate("name", "DESC", "NOCASE"),
+		},
+		{
+			name: "Select with Expr Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("order_items").
+				Where(Eq("status", "open"), Expr("price * quantity > ? OR note = '?'", 100), Gt("id", 5)),
+		},
+		{
+			name: "Select with Expr SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("order_items").
+				Where(Or(Expr("DATEDIFF(day, created_at, ?) > ?", "2024-01-01", 30), Expr("archived = 1"))),
+		},
+		{
+			name: "Select with ILike Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(ILike("full_name", "%smith%"), NotILike("email", "%@example.com")),
+		},
+		{
+			name: "Select with ILike Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("people").
+				Where(Gt("age", 10), ILike("full_name", "%smith%")),
+		},
+		{
+			name: "Select with Regexp Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(Regexp("email", "^[a-z]+@example\\.com$"), NotRegexp("full_name", "[0-9]")),
+		},
+		{
+			name: "Select with Regexp Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("people").
+				Where(Or(Regexp("email", "^admin@"), NotRegexp("full_name", "^test"))),
+		},
+		{
+			name: "Select with Regexp SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("people").
+				Where(And(Gt("age", 10), Regexp("email", "^admin@"))),
+			isError: true,
+		},
+		{
+			name: "Select with Array Operators Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("posts").
+				Where(EqAny("author_id", []int64{1, 2, 3}), ArrayContains("tags", []string{"go"}), ArrayOverlaps("categories", []string{"db", "sql"})),
+		},
+		{
+			name: "Select with Array Operators MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("posts").
+				Where(EqAny("author_id", []int64{1, 2, 3})),
+			isError: true,
+		},
+		{
+			name: "Select with Empty In Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(In("id"), NotIn("status", []any{}...), Gt("age", 10)),
+		},
+		{
+			name: "Select with Empty In Slice MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("people").
+				Where(Or(In("id", []int{}), Eq("full_name", "admin"))),
+		},
+		{
+			name: "Select with In Expansion Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(Gt("age", 10), In("id", []int{1, 2, 3}), NotIn("status", "banned", "deleted")),
+		},
+		{
+			name: "Select with In Expansion SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("people").
+				Where(In("id", 4, 5), Eq("active", true)),
+		},
+		{
+			name: "Select with Where Map Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				WhereMap(map[string]any{
+					"age >=":         18,
+					"full_name like": "%smith%",
+					"status IN":      []string{"active", "pending"},
+					"deleted_at IS":  nil,
+					"country":        "ID",
+				}),
+		},
+		{
+			name: "Select with Where Map Unknown Operator MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("people").
+				WhereMap(map[string]any{"age ~~": 18}),
+			isError: true,
+		},
+		{
+			name: "Select with Eq Nil SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("people").
+				Where(Eq("deleted_at", nil), NotEq("manager_id", (*int64)(nil)), Eq("active", true)),
+		},
+		{
+			name: "Select with Date Helpers Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("orders").
+				Where(OnDate("created_at", time.Date(2024, 3, 15, 13, 45, 0, 0, time.UTC)), WithinLast("updated_at", 24*time.Hour)),
+		},
+		{
+			name: "Select with Date Helpers SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("orders").
+				Where(BetweenDates("created_at", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+					WithinLast("updated_at", 90*time.Minute)),
+		},
+		{
+			name: "Select with Within Last SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("orders").
+				Where(WithinLast("created_at", 7*24*time.Hour)),
+		},
+		{
+			name: "Select with Column Comparisons MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("documents").
+				Where(ColumnGt("updated_at", "created_at"), ColumnLte("d.version", "d.max_version"), ColumnNeq("owner_id", "editor_id")),
+		},
+		{
+			name: "Select with Column Comparisons SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("documents").
+				Where(Or(ColumnGte("updated_at", "published_at"), ColumnLt("expires_at", "published_at"))),
+		},
+		{
+			name: "Select with Quantified Subqueries Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("products").
+				Where(Eq("active", true),
+					GtAll("price", New().WithDialect(NewPostgreSQLDialect()).Select("price").From("products").Where(Eq("category", "books"))),
+					EqAny("supplier_id", New().WithDialect(NewPostgreSQLDialect()).Select("id").From("suppliers").Where(Eq("country", "ID")))),
+		},
+		{
+			name: "Select with Lt Some Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("products").
+				Where(LtSome("price", New().WithDialect(NewOracleDialect()).Select("price").From("discounts").Where(Gt("rate", 0.1))), Eq("active", 1)),
+		},
+		{
+			name: "Select with Gt All SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("products").
+				Where(GtAll("price", New().WithDialect(NewSQLiteDialect()).Select("price").From("products"))),
+			isError: true,
+		},
+		{
+			name: "Select with Spatial Conditions Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("stores").
+				Where(Eq("open", true),
+					STDWithin("location", Geometry{WKT: "POINT(106.8 -6.2)", SRID: 4326}, 5000),
+					STWithin("location", Geometry{WKT: "POLYGON((106 -7, 107 -7, 107 -6, 106 -6, 106 -7))", SRID: 4326})),
+		},
+		{
+			name: "Select with Spatial Conditions SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("stores").
+				Where(STDWithin("location", Geometry{WKT: "POINT(106.8 -6.2)"}, 5000), STIntersects("area", Geometry{WKT: "POINT(106.8 -6.2)"})),
+		},
+		{
+			name: "Select with Spatial Conditions MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("stores").
+				Where(STIntersects("area", Geometry{WKT: "POINT(106.8 -6.2)", SRID: 4326}), STDWithin("location", Geometry{WKT: "POINT(106.8 -6.2)"}, 0.05)),
+		},
+		{
+			name: "Select with Spatial Conditions SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("stores").
+				Where(STWithin("location", Geometry{WKT: "POINT(1 1)"})),
+			isError: true,
+		},
+		{
+			name: "Select with Bitwise Conditions Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("users").
+				Where(HasFlag("permissions", 4), BitAnd("flags", 3, 1)),
+		},
+		{
+			name: "Select with Bitwise Conditions Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("id").From("users").
+				Where(Eq("active", 1), HasFlag("permissions", 4)),
+		},
+		{
+			name: "Select with Starts With and Contains Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("products").
+				Where(StartsWith("sku", "AB_1"), Contains("name", "50% off!")),
+		},
+		{
+			name: "Select with Ends With SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("files").
+				Where(EndsWith("name", "[draft]_v1.txt")),
+		},
+		{
+			name: "Select with Null Safe Eq MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("id").From("people").
+				Where(NullSafeEq("manager_id", nil), Gt("age", 10)),
+		},
+		{
+			name: "Select with Null Safe Eq Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(NullSafeEq("manager_id", 7)),
+		},
+		{
+			name: "Select with Null Safe Eq SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("id").From("people").
+				Where(NullSafeEq("manager_id", 7), Eq("active", true)),
+		},
+		{
+			name: "Select with Invalid Exists Subquery Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").
+				Where(Gt("age", 10), Or(Eq("vip", true), Exists(New().WithDialect(NewPostgreSQLDialect()).Select("1").From("")))),
+			isError: true,
+		},
+		{
+			name: "Select with Invalid Quantified Subquery in Having MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Select("dept").From("employees").GroupBy("dept").
+				Having(GtAll("MAX(salary)", New().WithDialect(NewMySQLDialect()).Select("salary").FromValues(nil, "m", "salary"))),
+			isError: true,
+		},
+		{
+			name: "Select with In Subquery Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").
+				Where(Gt("age", 10),
+					InSubquery("id", New().WithDialect(NewPostgreSQLDialect()).Select("person_id").From("orders").Where(Eq("status", "paid"))),
+					NotInSubquery("id", New().WithDialect(NewPostgreSQLDialect()).Select("person_id").From("bans").Where(Eq("active", true)))),
+		},
+		{
+			name: "Select with Where Group Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("products").
+				Where(Eq("active", true)).
+				WhereGroup(func(g ConditionGroup) {
+					for _, term := range []string{"red", "blue"} {
+						g.OrGroup(func(alt ConditionGroup) {
+							alt.Where(Contains("name", term), Contains("description", term))
+						})
+					}
+					g.Or(Lt("price", 10), Eq("on_sale", true))
+				}).
+				WhereGroup(func(g ConditionGroup) {}),
+		},
+		{
+			name: "Select with Not Conditions Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("users").
+				Where(Eq("status", "banned").Not(),
+					In("role", "guest", "bot").Not(),
+					Or(Like("email", "%@test.com"), IsNull("verified_at")).Not(),
+					Between("age", 13, 17).Not().Not(),
+					Not(Exists(New().WithDialect(NewPostgreSQLDialect()).Select("1").From("bans").Where(ColumnEq("bans.user_id", "users.id")))),
+					And().Not()),
+		},
+		{
+			name: "Select with Not Collated Condition SQLite",
+			sb: New().WithDialect(NewSQLiteDialect()).Select("id").From("users").
+				Where(Collate(Eq("name", "bob").Not(), "NOCASE")),
+		},
+		{
+			name:    "Select with Not nil Condition MySQL",
+			sb:      New().WithDialect(NewMySQLDialect()).Select("id").From("users").Where(Not(nil)),
+			isError: true,
+		},
+		{
+			name: "Select with table is nil MySQL",
+			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").From("").Where(Gt("age", 10)),
+			isError: true,
+		},
+		{
+			name: "Select with table in subquery is nil MySQL",
+			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").FromSubquery(&subquery{
+				builder: New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").From("people").Where(Gt("age", 10)),
+			}, "p").JoinSubquery(&subquery{
+				builder: New().WithDialect(NewMySQLDialect()).Select("order_id", "person_id"),
+			}, "o", "p.id = o.person_id"),
+			isError: true,
+		},
+		{
+			name: "Select with table in subquery FRPM is nil MySQL",
+			sb:   New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").FromSubquery(&subquery{
+				builder: New().WithDialect(NewMySQLDialect()).Select("id", "full_name", "age").Where(Gt("age", 10)),
+			}, "p"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.sb.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("should return error")
+			} else {
+				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+			}
+			if tt.expected != "" && query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestInsertSingleBasic(t *testing.T) {
+	tests := []struct {
+		name    string
+		ib 	InsertBuilder
+		isError bool
+	}{
+		{
+			name: "Insert MySQL",
+			ib:   New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full name", "age", "is_healthy").Values(1, "Arif", 10, false), 
+		},
+		{
+			name: "Insert Postgress",
+			ib:   New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full name", "age", "is_healthy").Values(1, "Arif", 10, false),
+		},
+		{
+			name: "Insert Values Rows Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full_name", "age").
+				ValuesRows([][]any{{1, "Arif", 10}, {2, "Budi", 20}}).
+				Values(3, "Citra", 30),
+		},
+		{
+			name: "Insert Values Rows without Columns SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("people").
+				ValuesRows([][]any{{1, "Arif"}, {2, "Budi"}}),
+		},
+		{
+			name: "Insert Returning Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("full_name").Values("Arif").
+				Returning("id", "created_at"),
+		},
+		{
+			name: "Insert Output SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("full_name").Values("Arif").
+				Returning("id", "created_at"),
+		},
+		{
+			name:    "Insert Output Default Values SQLServer",
+			ib:      New().WithDialect(NewSQLServerDialect()).Insert("people").DefaultValues().Returning("id"),
+		},
+		{
+			name:    "Insert Returning MySQL",
+			ib:      New().WithDialect(NewMySQLDialect()).Insert("people").Columns("full_name").Values("Arif").Returning("id"),
+			isError: true,
+		},
+		{
+			name:    "Insert Returning Oracle",
+			ib:      New().WithDialect(NewOracleDialect()).Insert("people").Columns("full_name").Values("Arif").Returning("id"),
+			isError: true,
+		},
+		{
+			name: "Insert From Select Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("archive").Columns("id", "full_name", "total").
+				FromSelect(New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").SelectRaw("COALESCE(total, ?)", 0).From("people")),
+		},
+		{
+			name: "Insert From Select Star MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("archive").Columns("id", "full_name").
+				FromSelect(New().WithDialect(NewMySQLDialect()).Select("*").From("people")),
+		},
+		{
+			name: "Insert with Timestamps Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).WithTimestamps("created_at", "updated_at").Insert("people").
+				Columns("id", "full_name").Values(1, "Arif").Values(2, "Budi").
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name")}}),
+		},
+		{
+			name: "Insert with Timestamps already Set MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).WithTimestamps("created_at", "").Insert("people").
+				Columns("id", "CREATED_AT").Values(1, "2024-01-01"),
+		},
+		{
+			name: "Insert with Timestamps Merge Oracle",
+			ib: New().WithDialect(NewOracleDialect()).WithTimestamps("created_at", "updated_at").Insert("people").
+				Columns("id", "full_name").Values(1, "Arif").
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name")}}),
+		},
+		{
+			name: "Insert with Expression Values Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("users").Columns("id", "email", "location").
+				Values(1, ExprValue("LOWER(?)", "Arif@Example.com"), ExprValue("ST_SetSRID(ST_MakePoint(?, ?), ?)", 106.8, -6.2, UnsafeRaw("4326"))).
+				Values(2, (&insertBuilder{}).Func("LOWER", "Budi@Example.com"), nil),
+		},
+		{
+			name: "Insert with Expression Values Merge SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("users").Columns("id", "email").
+				Values(1, ExprValue("LOWER(?)", "Arif@Example.com")).
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"email": ExprValue("LOWER(?)", "arif@example.com")}}),
+		},
+		{
+			name: "Insert with Expression Value missing Args MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("users").Columns("id", "email").
+				Values(1, ExprValue("CONCAT(?, ?)", "a")),
+			isError: true,
+		},
+		{
+			name: "Insert With CTE Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("archive").Columns("id", "full_name").
+				With("ranked", New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").Where(Lt("last_login", "2020-01-01"))).
+				FromSelect(New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("ranked").Where(Gt("id", 100))).
+				Returning("id"),
+		},
+		{
+			name: "Insert With CTE MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("archive").
+				With("ranked", New().WithDialect(NewMySQLDialect()).Select("id").From("people").Where(Eq("active", false))).
+				FromSelect(New().WithDialect(NewMySQLDialect()).Select("*").From("ranked")),
+		},
+		{
+			name: "Insert With CTE Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("archive").Columns("id").
+				With("ranked", New().WithDialect(NewOracleDialect()).Select("id").From("people").Where(Eq("active", 0))).
+				FromSelect(New().WithDialect(NewOracleDialect()).Select("id").From("ranked").Where(Gt("id", 100))),
+		},
+		{
+			name: "Insert With CTE and Values MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("archive").Columns("id").Values(1).
+				With("ranked", New().WithDialect(NewMySQLDialect()).Select("id").From("people")),
+			isError: true,
+		},
+		{
+			name: "Insert From Select with wrong column count Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("archive").Columns("id", "full_name", "age").
+				FromSelect(New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people")),
+			isError: true,
+		},
+		{
+			name: "Insert Values Rows with wrong arity MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full_name").
+				ValuesRows([][]any{{1, "Arif"}, {2}}),
+			isError: true,
+		},
+		{
+			name:    "Insert Values Rows with uneven rows SQLite",
+			ib:      New().WithDialect(NewSQLiteDialect()).Insert("people").ValuesRows([][]any{{1, "Arif"}, {2, "Budi", 20}}),
+			isError: true,
+		},
+		{
+			name: "Insert Set Map Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("people").
+				SetMap(map[string]any{"full_name": "Arif", "age": 10, "id": 1}).
+				SetMap(map[string]any{"id": 2, "age": 20, "full_name": "Budi"}),
+		},
+		{
+			name: "Insert Set Map with different keys MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").
+				SetMap(map[string]any{"id": 1, "full_name": "Arif"}).
+				SetMap(map[string]any{"id": 2, "age": 20}),
+			isError: true,
+		},
+		{
+			name:    "Insert Set Map empty SQLite",
+			ib:      New().WithDialect(NewSQLiteDialect()).Insert("people").SetMap(map[string]any{}),
+			isError: true,
+		},
+		{
+			name: "Insert On Duplicate Key Update MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full_name", "age").Values(1, "Arif", 10).
+				OnConflict(ConflictAction{DoUpdate: map[string]any{"full_name": Excluded("full_name"), "age": 11, "updated_at": UnsafeRaw("NOW()")}}),
+		},
+		{
+			name: "Insert On Duplicate Key Update with Row Alias MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full_name").Values(1, "Arif").
+				OnConflict(ConflictAction{RowAlias: "new", DoUpdate: map[string]any{"full_name": Excluded("full_name")}}),
+		},
+		{
+			name: "Insert Ignore MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full_name").Values(1, "Arif").
+				OnConflict(ConflictAction{DoNothing: true}),
+		},
+		{
+			name: "Insert On Duplicate Key No-op Update MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("people").Columns("id", "full_name").Values(1, "Arif").
+				OnConflict(ConflictAction{Target: "id", DoNothing: true}),
+		},
+		{
+			name: "Insert On Conflict On Constraint with Update Where Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("page_views").Columns("page_id", "hits").Values(1, 1).
+				OnConflict(ConflictAction{
+					Constraint:  "page_views_pkey",
+					DoUpdate:    map[string]any{"hits": NewFragment("page_views.hits + ?", Excluded("hits")), "updated_at": UnsafeRaw("NOW()")},
+					UpdateWhere: Eq("page_views.locked", false),
+				}),
+		},
+		{
+			name: "Insert On Conflict Partial Index SQLite",
+			ib: New().WithDialect(NewSQLiteDialect()).Insert("users").Columns("email", "name").Values("a@b.c", "Arif").
+				OnConflict(ConflictAction{Target: "email", TargetWhere: IsNull("deleted_at"), DoUpdate: map[string]any{"name": Excluded("name")}}),
+		},
+		{
+			name: "Insert On Conflict Do Update without Target Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("users").Columns("email").Values("a@b.c").
+				OnConflict(ConflictAction{DoUpdate: map[string]any{"email": Excluded("email")}}),
+			isError: true,
+		},
+		{
+			name: "Insert On Conflict On Constraint SQLite",
+			ib: New().WithDialect(NewSQLiteDialect()).Insert("users").Columns("email").Values("a@b.c").
+				OnConflict(ConflictAction{Constraint: "users_email_key", DoNothing: true}),
+			isError: true,
+		},
+		{
+			name: "Insert On Duplicate Key with Fragment MySQL",
+			ib: New().WithDialect(NewMySQLDialect()).Insert("page_views").Columns("page_id", "hits").Values(1, 1).
+				OnConflict(ConflictAction{DoUpdate: map[string]any{"hits": NewFragment("hits + ?", Excluded("hits"))}}),
+		},
+		{
+			name: "Insert Merge with Update Where SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("page_views").Columns("page_id", "hits").Values(1, 1).
+				OnConflict(ConflictAction{Target: "page_id", DoUpdate: map[string]any{"hits": NewFragment("target.hits + ?", Excluded("hits"))}, UpdateWhere: Eq("target.locked", 0)}),
+		},
+		{
+			name: "Insert Merge with Update Where Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("page_views").Columns("page_id", "hits").Values(1, 1).
+				OnConflict(ConflictAction{Target: "page_id", DoUpdate: map[string]any{"hits": Excluded("hits")}, UpdateWhere: Eq("target.locked", 0)}),
+		},
+		{
+			name: "Insert On Conflict Do Update Excluded Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full_name", "age").Values(1, "Arif", 10).
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name"), "age": 11}}),
+		},
+		{
+			name: "Insert Merge Upsert SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("id", "tenant_id", "full_name", "age").
+				Values(1, 7, "Arif", 10).Values(2, 7, "Budi", 20).
+				OnConflict(ConflictAction{Target: "id, tenant_id", DoUpdate: map[string]any{"full_name": Excluded("full_name"), "age": 11}}).
+				Returning("id"),
+		},
+		{
+			name: "Insert Merge Do Nothing from Select SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("id", "full_name").
+				Prefix("SET XACT_ABORT ON;").
+				FromSelect(New().WithDialect(NewSQLServerDialect()).Select("id", "full_name").From("staging").Where(Eq("batch", 3))).
+				OnConflict(ConflictAction{Target: "id", DoNothing: true}),
+		},
+		{
+			name: "Insert Merge without Target SQLServer",
+			ib: New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("id").Values(1).
+				OnConflict(ConflictAction{DoNothing: true}),
+			isError: true,
+		},
+		{
+			name: "Insert Merge Upsert Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("people").Columns("id", "full_name", "age").
+				Values(1, "Arif", 10).Values(2, "Budi", 20).
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name"), "age": 11}}),
+		},
+		{
+			name: "Insert Merge Do Nothing from Select Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("people").Columns("id", "full_name").
+				FromSelect(New().WithDialect(NewOracleDialect()).Select("id", "full_name").From("staging").Where(Eq("batch", 3))).
+				OnConflict(ConflictAction{Target: "id", DoNothing: true}),
+		},
+		{
+			name: "Insert Merge updating Target Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("people").Columns("id", "full_name").Values(1, "Arif").
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"id": Excluded("id")}}),
+			isError: true,
+		},
+		{
+			name: "Insert Merge with Returning Oracle",
+			ib: New().WithDialect(NewOracleDialect()).Insert("people").Columns("id").Values(1).
+				OnConflict(ConflictAction{Target: "id", DoNothing: true}).Returning("id"),
+			isError: true,
+		},
+		{
+			name:    "Insert Values Rows with empty row Oracle",
+			ib:      New().WithDialect(NewOracleDialect()).Insert("people").ValuesRows([][]any{{}}),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.ib.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("Should return error")
+			} else {
+				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
+			}
+		})
+	}
+}
+
+type Audit struct {
+	UpdatedBy string `db:"updated_by"`
+}
+
+type personPatch struct {
+	Name  *string `db:"full_name"`
+	Age   *int    `db:"age"`
+	Email string  `db:"email,omitempty"`
+	Notes string  `db:"-"`
+	Audit
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestUpdateBasic(t *testing.T) {
+	tests := []struct {
+		name     string
+		ub 	UpdateBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Update MySQL",
+			ub:   New().WithDialect(NewMySQLDialect()).Update("people").SetValues(map[string]any{
+				"fullname":   "Arif Setiawan",
+				"occupation": "Software Engineer",
+			}).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Suffix SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("people").Set("fullname", "Arif Setiawan").
+				Where(Eq("id", 1)).Suffix("OPTION (MAXDOP 1)"),
+		},
+		{
+			name: "Update with Timestamps SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).WithTimestamps("created_at", "updated_at").Update("people").
+				Set("fullname", "Arif Setiawan").Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Timestamps already Set Oracle",
+			ub: New().WithDialect(NewOracleDialect()).WithTimestamps("created_at", "updated_at").Update("people").
+				Set("fullname", "Arif Setiawan").Set("updated_at", "2024-01-01").Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Expression Value Oracle",
+			ub: New().WithDialect(NewOracleDialect()).Update("people").
+				Set("email", ExprValue("LOWER(?)", "Arif@Example.com")).Set("age", 11).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Expression Value extra Args Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Update("people").
+				Set("email", ExprValue("LOWER(?)", "a", "b")).Where(Eq("id", 1)),
+			isError: true,
+		},
+		{
+			name: "Update with Incr and Decr Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Update("products").
+				Decr("stock", 2).Incr("sold", 2).Incr("version", 1).Where(Eq("id", 7), GtOrEq("stock", 2)),
+		},
+		{
+			name: "Update with Incr SQLServer",
+			ub:   New().WithDialect(NewSQLServerDialect()).Update("pages").Incr("hits", 1).Where(Eq("id", 7)),
+		},
+		{
+			name: "Update with Set Struct Skip Zero Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Update("people").
+				SetStruct(&personPatch{Name: ptr("Arif"), Email: "arif@example.com"}, SkipZero()).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Set Struct Only Fields MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Update("people").
+				SetStruct(personPatch{Name: ptr("Arif"), Audit: Audit{UpdatedBy: "admin"}}, OnlyFields("Age", "full_name", "updated_by")).Where(Eq("id", 1)),
+		},
+		{
+			name:    "Update with Set Struct not a Struct SQLite",
+			ub:      New().WithDialect(NewSQLiteDialect()).Update("people").SetStruct(map[string]any{"age": 1}).Where(Eq("id", 1)),
+			isError: true,
+		},
+		{
+			name: "Update with CTE Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Update("jobs j").
+				With("todo", New().WithDialect(NewPostgreSQLDialect()).Select("id").From("jobs").Where(Eq("status", "pending")).
+					OrderBy("id", "ASC").Limit(100).ForUpdate().SkipLocked()).
+				From("todo").Set("status", "running").Where(Expr("j.id = todo.id")).Returning("j.id"),
+		},
+		{
+			name: "Update with CTE Oracle",
+			ub: New().WithDialect(NewOracleDialect()).Update("jobs").
+				With("todo", New().WithDialect(NewOracleDialect()).Select("id").From("jobs")).
+				Set("status", "running"),
+			isError: true,
+		},
+		{
+			name: "Update with Top SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("jobs").Set("status", "running").
+				Where(Eq("status", "pending")).Limit(100),
+		},
+		{
+			name: "Update with Top and Order By SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("jobs").Set("status", "running").
+				OrderBy("id", "ASC").Limit(100),
+			isError: true,
+		},
+		{
+			name: "Update with Set If MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Update("people").
+				SetIf(true, "full_name", "Arif").SetIf(false, "age", 0).
+				Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Output SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("people").Set("age", 11).
+				Where(Eq("id", 1)).Returning("id", "age"),
+		},
+		{
+			name: "Update with Returning SQLite",
+			ub:   New().WithDialect(NewSQLiteDialect()).Update("people").Set("age", 11).Where(Eq("id", 1)).Returning("id"),
+		},
+		{
+			name:    "Update with Returning MySQL",
+			ub:      New().WithDialect(NewMySQLDialect()).Update("people").Set("age", 11).Where(Eq("id", 1)).Returning("id"),
+			isError: true,
+		},
+		{
+			name:    "Update with Returning Oracle",
+			ub:      New().WithDialect(NewOracleDialect()).Update("people").Set("age", 11).Where(Eq("id", 1)).Returning("id"),
+			isError: true,
+		},
+		{
+			name:    "Update without Where Safe Postgress",
+			ub:      New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().Update("people").Set("age", 11),
+			isError: true,
+		},
+		{
+			name:    "Update with empty Where Safe Postgress",
+			ub:      New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().Update("people").Set("age", 11).WhereIf(false, Eq("id", 1)).Where(And()),
+			isError: true,
+		},
+		{
+			name:    "Update from NewUpdateBuilder without Where Safe Postgress",
+			ub:      New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().(*QueryBuilder).NewUpdateBuilder().Table("people").Set("age", 11),
+			isError: true,
+		},
+		{
+			name: "Update from NewUpdateBuilder with UpdatedAt Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).WithUpdatedAt("modified_at", "people").(*QueryBuilder).NewUpdateBuilder().
+				Table("people").Set("age", 11).Where(Eq("id", 1)),
+			expected: `UPDATE "people" SET age = $1, modified_at = NOW() WHERE id = $2`,
+		},
+		{
+			name: "Update AllRows Safe Postgress",
+			ub:   New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().Update("people").Set("age", 11).AllRows(),
+		},
+		{
+			name: "Update with Where Safe Postgress",
+			ub:   New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().Update("people").Set("age", 11).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with UpdatedAt configured Table Postgress",
+			ub:   New().WithDialect(NewPostgreSQLDialect()).WithUpdatedAt("modified_at", "people", "orders").Update("people").Set("age", 11).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with UpdatedAt aliased Table SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).WithUpdatedAt("modified_at", "orders").Update("Orders o").
+				Set("status", "paid").Where(Eq("o.id", 1)),
+		},
+		{
+			name: "Update with UpdatedAt other Table Postgress",
+			ub:   New().WithDialect(NewPostgreSQLDialect()).WithUpdatedAt("modified_at", "orders").Update("people").Set("age", 11).Where(Eq("id", 1)),
+		},
+		{
+			name: "Update with Join and Output SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("orders o").Set("o.status", "closed").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")).Returning("o.id"),
+			expected: "UPDATE [o] SET o.status = @p1 OUTPUT INSERTED.id FROM [orders] [o] INNER JOIN [customers] [c] ON c.id = o.customer_id WHERE c.status = @p2",
+		},
+		{
+			name: "Update with Join MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Update("orders o").
+				Join("customers c", "c.id = o.customer_id").LeftJoin("vouchers v", "v.order_id = o.id").
+				SetRaw("o.discount", "c.discount").Set("o.status", "priced").Where(Eq("c.tier", "gold")),
+		},
+		{
+			name: "Update multi Table MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).WithTimestamps("created_at", "updated_at").Update("orders o").
+				Join("customers c", "c.id = o.customer_id").
+				SetColumn("o.discount", "c.discount").SetColumn("c.last_order_id", "o.id").Set("o.status", "priced").
+				Where(Eq("c.tier", "gold")),
+		},
+		{
+			name: "Update multi Table with invalid Column MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Update("orders o").Join("customers c", "c.id = o.customer_id").
+				SetColumn("o.discount", "c.discount; DROP TABLE orders").Where(Eq("c.tier", "gold")),
+			isError: true,
+		},
+		{
+			name: "Update with From Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Update("orders o").From("customers c").
+				Join("tiers t", "t.id = c.tier_id").
+				SetRaw("discount", "t.discount").Where(Expr("c.id = o.customer_id"), Eq("t.name", "gold")).Returning("o.id"),
+		},
+		{
+			name: "Update with Join SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Update("orders o").
+				Join("customers c", "c.id = o.customer_id").
+				SetRaw("discount", "c.discount").Where(Eq("c.tier", "gold")),
+		},
+		{
+			name: "Update with Join without From SQLite",
+			ub: New().WithDialect(NewSQLiteDialect()).Update("orders").
+				Join("customers c", "c.id = orders.customer_id").Set("status", "priced"),
+			isError: true,
+		},
+		{
+			name: "Update with From Oracle",
+			ub: New().WithDialect(NewOracleDialect()).Update("orders").From("customers").Set("status", "priced"),
+			isError: true,
+		},
+		{
+			name: "Update Postgress",
+			ub:   New().WithDialect(NewPostgreSQLDialect()).Update("people").SetValues(map[string]any{
+				"fullname":   "Arif Setiawan",
+				"occupation": "Software Engineer",
+			}).Where(Eq("id", 1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.ub.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("Should return error")
+			} else {
+				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
+			}
+			if tt.expected != "" && query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestDeleteBasic(t *testing.T) {
+	tests := []struct {
+		name     string
+		db DeleteBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Delete MySQL",
+			db:   New().WithDialect(NewMySQLDialect()).Delete("people").Where(Eq("id", 1)),
+		},
+		{
+			name: "Delete with Comment Postgress",
+			db:   New().WithDialect(NewPostgreSQLDialect()).Delete("people").Comment("job=cleanup").Where(Eq("id", 1)),
+		},
+		{
+			name:    "Delete with Regexp SQLServer",
+			db:      New().WithDialect(NewSQLServerDialect()).Delete("people").Where(Regexp("email", "^test")),
+			isError: true,
+		},
+		{
+			name:    "Delete with Invalid Exists Subquery Postgress",
+			db:      New().WithDialect(NewPostgreSQLDialect()).Delete("people").Where(NotExists(New().WithDialect(NewPostgreSQLDialect()).Select("1"))),
+			isError: true,
+		},
+		{
+			name: "Delete with Returning Postgress",
+			db:   New().WithDialect(NewPostgreSQLDialect()).Delete("people").Where(Eq("id", 1)).Returning("id", "full_name"),
+		},
+		{
+			name:    "Delete with Returning MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).Delete("people").Where(Eq("id", 1)).Returning("id"),
+			isError: true,
+		},
+		{
+			name: "Delete with Using Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("orders o").Using("customers c").
+				UsingSubquery(New().WithDialect(NewPostgreSQLDialect()).Select("id").From("blocked").Where(Eq("reason", "fraud")), "b").
+				Where(ColumnEq("c.id", "o.customer_id"), ColumnEq("b.id", "c.id"), Eq("o.status", "open")).Returning("o.id"),
+		},
+		{
+			name: "Delete with Using MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders o").Using("customers c").
+				Where(ColumnEq("c.id", "o.customer_id"), Eq("c.status", "closed")),
+		},
+		{
+			name: "Delete with Using SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders").Using("customers c").
+				Where(ColumnEq("c.id", "orders.customer_id"), Eq("c.status", "closed")).Limit(100),
+		},
+		{
+			name:    "Delete with Using SQLite",
+			db:      New().WithDialect(NewSQLiteDialect()).Delete("orders").Using("customers c").Where(ColumnEq("c.id", "orders.customer_id")),
+			isError: true,
+		},
+		{
+			name:    "Delete with Using and Limit MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).Delete("orders o").Using("customers c").Where(ColumnEq("c.id", "o.customer_id")).Limit(10),
+			isError: true,
+		},
+		{
+			name: "Delete with Join MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders o").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")),
+		},
+		{
+			name: "Delete multi Table Targets MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders o").DeleteFrom("o", "i").
+				LeftJoin("order_items i", "i.order_id = o.id").Where(Lt("o.created_at", "2020-01-01")),
+		},
+		{
+			name: "Delete with Join SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders o").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")),
+		},
+		{
+			name: "Delete multi Table Targets SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders o").DeleteFrom("o", "c").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")),
+			isError: true,
+		},
+		{
+			name: "Delete with Join Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("orders").Join("customers c", "c.id = orders.customer_id").
+				JoinOn("regions r", ColumnEq("r.id", "c.region_id"), Eq("r.code", "EU")).Where(Eq("c.status", "closed")),
+			expected: `DELETE FROM "orders" USING "customers" "c", "regions" "r" WHERE (c.id = orders.customer_id) AND ("r"."id" = "c"."region_id" AND r.code = $1) AND c.status = $2`,
+		},
+		{
+			name: "Delete with Using and Join Subquery Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("orders o").Using("customers c").
+				JoinSubquery(New().WithDialect(NewPostgreSQLDialect()).Select("id").From("blocked").Where(Eq("reason", "fraud")), "b", "b.id = c.id").
+				Where(ColumnEq("c.id", "o.customer_id")),
+			expected: `DELETE FROM "orders" "o" USING "customers" "c", (SELECT id FROM "blocked" WHERE reason = $1) AS "b" WHERE (b.id = c.id) AND "c"."id" = "o"."customer_id"`,
+		},
+		{
+			name:    "Delete with Left Join Postgress",
+			db:      New().WithDialect(NewPostgreSQLDialect()).Delete("orders o").LeftJoin("customers c", "c.id = o.customer_id"),
+			isError: true,
+		},
+		{
+			name:    "Delete with Join SQLite",
+			db:      New().WithDialect(NewSQLiteDialect()).Delete("orders").Join("customers c", "c.id = orders.customer_id"),
+			isError: true,
+		},
+		{
+			name:    "Delete with Join Oracle",
+			db:      New().WithDialect(NewOracleDialect()).Delete("orders").JoinOn("customers c", ColumnEq("c.id", "orders.customer_id")),
+			isError: true,
+		},
+		{
+			name:    "Delete multi Table Targets Postgress",
+			db:      New().WithDialect(NewPostgreSQLDialect()).Delete("orders o").DeleteFrom("o").Using("customers c").Where(ColumnEq("c.id", "o.customer_id")),
+			isError: true,
+		},
+		{
+			name: "Soft Delete Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("people").SoftDelete("deleted_at").
+				Where(Eq("id", 1)).Returning("id"),
+		},
+		{
+			name: "Soft Delete with Join MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders o").SoftDelete("o.deleted_at").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")),
+		},
+		{
+			name: "Soft Delete with Using Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("orders o").SoftDelete("deleted_at").
+				Using("customers c").Where(ColumnEq("c.id", "o.customer_id"), Eq("c.status", "closed")),
+		},
+		{
+			name: "Soft Delete with Top SQLServer",
+			db:   New().WithDialect(NewSQLServerDialect()).Delete("logs").SoftDelete("deleted_at").Where(Lt("created_at", "2020-01-01")).Limit(500),
+		},
+		{
+			name:    "Soft Delete without Where Safe Postgress",
+			db:      New().WithDialect(NewPostgreSQLDialect()).WithSafeUpdates().Delete("orders").SoftDelete("deleted_at"),
+			isError: true,
+		},
+		{
+			name: "Soft Delete with UpdatedAt Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).WithUpdatedAt("modified_at", "orders").WithSafeUpdates().
+				Delete("orders").SoftDelete("deleted_at").Where(Eq("id", 1)),
+			expected: `UPDATE "orders" SET deleted_at = NOW(), modified_at = NOW() WHERE id = $1`,
+		},
+		{
+			name:    "Soft Delete with Targets MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).Delete("orders o").SoftDelete("deleted_at").DeleteFrom("o").Join("customers c", "c.id = o.customer_id"),
+			isError: true,
+		},
+		{
+			name: "Delete with Alias Postgress",
+			db: New().WithDialect(NewPostgreSQLDialect()).Delete("orders").As("o").
+				Where(Exists(New().WithDialect(NewPostgreSQLDialect()).Select("1").From("refunds r").Where(ColumnEq("r.order_id", "o.id")))),
+		},
+		{
+			name: "Delete with Alias MySQL",
+			db:   New().WithDialect(NewMySQLDialect()).Delete("orders").As("o").Where(Eq("o.status", "void")),
+			expected: "DELETE FROM `orders` AS `o` WHERE o.status = ?",
+		},
+		{
+			name: "Delete with Alias and Limit MySQL",
+			db:   New().WithDialect(NewMySQLDialect()).Delete("orders").As("o").Where(Eq("o.status", "void")).
+				OrderBy("o.id", "ASC").Limit(10),
+			expected: "DELETE FROM `orders` AS `o` WHERE o.status = ? ORDER BY o.id ASC LIMIT ?",
+		},
+		{
+			name: "Delete with Join escaped MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders").
+				Join("customers c", "c.id = orders.customer_id").
+				Where(Eq("c.status", "closed")),
+			expected: "DELETE `orders` FROM `orders` INNER JOIN `customers` `c` ON c.id = orders.customer_id WHERE c.status = ?",
+		},
+		{
+			name: "Delete with Alias and Join SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders").As("o").
+				Join("customers c", "c.id = o.customer_id").Where(Eq("c.status", "closed")),
+		},
+		{
+			name: "Delete with Alias Oracle",
+			db:   New().WithDialect(NewOracleDialect()).Delete("orders").As("o").Where(Eq("o.status", "void")),
+			expected: "DELETE FROM orders o WHERE o.status = :1",
+		},
+		{
+			name: "Soft Delete with Alias Postgress",
+			db:   New().WithDialect(NewPostgreSQLDialect()).Delete("orders").As("o").SoftDelete("deleted_at").Where(Eq("o.status", "void")),
+		},
+		{
+			name: "Delete with Output SQLServer",
+			db:   New().WithDialect(NewSQLServerDialect()).Delete("people").Where(Eq("id", 1)).Returning("id", "full_name"),
+		},
+		{
+			name: "Delete with Join and Output SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders o").Join("customers c", "c.id = o.customer_id").
+				Where(Eq("c.status", "closed")).Returning("o.id"),
+		},
+		{
+			name:    "Delete with Returning Oracle",
+			db:      New().WithDialect(NewOracleDialect()).Delete("people").Where(Eq("id", 1)).Returning("id"),
+			isError: true,
+		},
+		{
+			name: "Delete with Join On MySQL",
+			db: New().WithDialect(NewMySQLDialect()).Delete("orders").As("o").
+				JoinOn("customers c", ColumnEq("c.id", "o.customer_id"), Eq("c.status", "closed")).Where(Lt("o.created_at", "2020-01-01")),
+		},
+		{
+			name: "Delete with Join Subquery SQLServer",
+			db: New().WithDialect(NewSQLServerDialect()).Delete("orders o").
+				JoinSubquery(New().WithDialect(NewSQLServerDialect()).Select("id").From("customers").Where(Eq("status", "closed")), "c", "c.id = o.customer_id").
+				Where(Lt("o.created_at", "2020-01-01")),
+		},
+		{
+			name:    "Soft Delete with Join On MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).Delete("orders o").SoftDelete("o.deleted_at").JoinOn("customers c", ColumnEq("c.id", "o.customer_id")),
+			isError: true,
+		},
+		{
+			name: "Delete with Top SQLServer",
+			db:   New().WithDialect(NewSQLServerDialect()).Delete("logs").Where(Lt("created_at", "2020-01-01")).Limit(500),
+		},
+		{
+			name: "Delete Postgress",
+			db:   New().WithDialect(NewPostgreSQLDialect()).Delete("people").Where(Eq("id", 1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.db.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("Should return error")
+			} else {
+				t.Logf("query ==========> %s ------- arguments ==========> %+v", query, args)
+			}
+			if tt.expected != "" && query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestSelectClone(t *testing.T) {
+	base := New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").Where(Gt("age", 10))
+	baseQuery, _, _ := base.ToSQL()
+
+	page := base.Clone().Where(Eq("status", "active")).OrderBy("id", "ASC").Limit(10).Offset(20)
+	pageQuery, pageArgs, err := page.ToSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("query ===> %s  ====> arguments =====> %+v", pageQuery, pageArgs)
+
+	query, _, _ := base.ToSQL()
+	if query != baseQuery {
+		t.Errorf("base query changed after clone: %s", query)
+	}
+}
+
+func TestSelectToCountSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		sb       SelectBuilder
+		expected string
+	}{
+		{
+			name: "Count Basic Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").
+				Where(Gt("age", 10)).OrderBy("id", "ASC").Limit(10).Offset(20),
+			expected: `SELECT COUNT(*) FROM "people" WHERE age > $1`,
+		},
+		{
+			name: "Count Grouped with CTE Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("p.id", "COUNT(o.order_id)").
+				With("adults", New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").Where(Gt("age", 17))).
+				From("adults p").
+				JoinOn("orders o", ColumnEq("p.id", "o.person_id"), Eq("o.status", "paid")).
+				GroupBy("p.id").Limit(10),
+			expected: `WITH adults AS (SELECT id FROM "people" WHERE age > $1) SELECT COUNT(*) FROM (SELECT p.id, COUNT(o.order_id) FROM "adults" "p" ` +
+				`INNER JOIN "orders" "o" ON "p"."id" = "o"."person_id" AND o.status = $2 GROUP BY p.id) AS count_query`,
+		},
+		{
+			name: "Count Distinct SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("person_id").From("orders").
+				Where(Eq("status", "paid")).Distinct().Limit(10),
+			expected: "SELECT COUNT(*) FROM (SELECT DISTINCT person_id FROM [orders] WHERE status = @p1) AS count_query",
+		},
+		{
+			name: "Count Grouped Oracle",
+			sb: New().WithDialect(NewOracleDialect()).Select("dept", "COUNT(*)").From("employees").
+				Where(Eq("active", 1)).GroupBy("dept").OrderBy("dept", "ASC"),
+			expected: "SELECT COUNT(*) FROM (SELECT dept, COUNT(*) FROM employees WHERE active = :1 GROUP BY dept) count_query",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.sb.ToCountSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestCreateTableAs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cb      SQLBuilder
+		isError bool
+	}{
+		{
+			name: "Create Table As Postgress",
+			cb: New().WithDialect(NewPostgreSQLDialect()).CreateTableAs("people_archive",
+				New().WithDialect(NewPostgreSQLDialect()).Select("id", "full_name").From("people").Where(Lt("created_at", "2020-01-01"))),
+		},
+		{
+			name: "Create Table As SQLServer",
+			cb: New().WithDialect(NewSQLServerDialect()).CreateTableAs("people_archive",
+				New().WithDialect(NewSQLServerDialect()).Select("id").From("people")),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.cb.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("should return error")
+			} else {
+				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+			}
+		})
+	}
+}
+
+func TestCompoundSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		cs       CompoundSelect
+		isError  bool
+		expected string
+	}{
+		{
+			name: "Union All with Outer Limit Postgress",
+			cs: New().WithDialect(NewPostgreSQLDialect()).
+				Compound(New().WithDialect(NewPostgreSQLDialect()).Select("id", "created_at").From("posts").Where(Eq("author_id", 7)).OrderBy("created_at", "DESC").Limit(5)).
+				UnionAll(New().WithDialect(NewPostgreSQLDialect()).Select("id", "created_at").From("comments").Where(Eq("author_id", 7))).
+				OrderBy("created_at", "DESC").Limit(10).Offset(20),
+			expected: `(SELECT id, created_at FROM "posts" WHERE author_id = $1 ORDER BY created_at DESC LIMIT $2) UNION ALL (SELECT id, created_at FROM "comments" WHERE author_id = $3) ORDER BY created_at DESC LIMIT $4 OFFSET $5`,
+		},
+		{
+			name: "Union with Outer Limit SQLite",
+			cs: New().WithDialect(NewSQLiteDialect()).
+				Compound(New().WithDialect(NewSQLiteDialect()).Select("id").From("posts").OrderBy("id", "DESC").Limit(5)).
+				Union(New().WithDialect(NewSQLiteDialect()).Select("id").From("comments")).
+				Limit(10),
+			expected: `SELECT * FROM (SELECT id FROM "posts" ORDER BY id DESC LIMIT ?) UNION SELECT * FROM (SELECT id FROM "comments") LIMIT ?`,
+		},
+		{
+			name: "Union with Branch Limit SQLServer",
+			cs: New().WithDialect(NewSQLServerDialect()).
+				Compound(New().WithDialect(NewSQLServerDialect()).Select("id").From("posts").OrderBy("id", "DESC").Limit(5)).
+				Union(New().WithDialect(NewSQLServerDialect()).Select("id").From("comments")),
+			expected: "SELECT * FROM (SELECT id FROM [posts] ORDER BY id DESC OFFSET 0 ROWS FETCH NEXT @p1 ROWS ONLY) AS b UNION SELECT * FROM (SELECT id FROM [comments]) AS b",
+		},
+		{
+			name: "Except with Outer Limit SQLServer",
+			cs: New().WithDialect(NewSQLServerDialect()).
+				Compound(New().WithDialect(NewSQLServerDialect()).Select("id").From("people").Where(Gt("age", 10))).
+				Except(New().WithDialect(NewSQLServerDialect()).Select("person_id").From("bans").Where(Eq("active", true))).
+				Limit(10),
+			expected: "SELECT * FROM (SELECT id FROM [people] WHERE age > @p1) AS b EXCEPT SELECT * FROM (SELECT person_id FROM [bans] WHERE active = @p2) AS b ORDER BY (SELECT NULL) OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY",
+		},
+		{
+			name: "Union with Branch Limit MySQL",
+			cs: New().WithDialect(NewMySQLDialect()).
+				Compound(New().WithDialect(NewMySQLDialect()).Select("id").From("posts").OrderBy("id", "DESC").Limit(5)).
+				Union(New().WithDialect(NewMySQLDialect()).Select("id").From("comments")),
+			expected: "(SELECT id FROM `posts` ORDER BY id DESC LIMIT ?) UNION (SELECT id FROM `comments`)",
+		},
+		{
+			name: "Except Oracle",
+			cs: New().WithDialect(NewOracleDialect()).
+				Compound(New().WithDialect(NewOracleDialect()).Select("id").From("people")).
+				Except(New().WithDialect(NewOracleDialect()).Select("person_id").From("bans")),
+			expected: "(SELECT id FROM people) MINUS (SELECT person_id FROM bans)",
+		},
+		{
+			name: "Single Query MySQL",
+			cs: New().WithDialect(NewMySQLDialect()).
+				Compound(New().WithDialect(NewMySQLDialect()).Select("id").From("people")),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.cs.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestTreeQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		tq      TreeQuery
+		isError bool
+	}{
+		{
+			name: "Tree with Max Depth Postgress",
+			tq: New().WithDialect(NewPostgreSQLDialect()).Tree("categories", "id", "parent_id").
+				Columns("name").StartWith(Eq("slug", "root")).MaxDepth(3),
+		},
+		{
+			name: "Tree MySQL",
+			tq: New().WithDialect(NewMySQLDialect()).Tree("categories", "id", "parent_id").
+				Columns("id", "name").StartWith(IsNull("parent_id")).As("category_tree"),
+		},
+		{
+			name: "Tree SQLServer",
+			tq: New().WithDialect(NewSQLServerDialect()).Tree("employees", "id", "manager_id").
+				Columns("name").StartWith(Eq("id", 1)).MaxDepth(2),
+		},
+		{
+			name: "Tree with Max Depth Oracle",
+			tq: New().WithDialect(NewOracleDialect()).Tree("employees", "id", "manager_id").
+				Columns("name").StartWith(Eq("id", 1)).MaxDepth(2),
+		},
+		{
+			name:    "Tree without Root",
+			tq:      New().WithDialect(NewSQLiteDialect()).Tree("categories", "id", "parent_id"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.tq.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("should return error")
+			} else {
+				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+			}
+		})
+	}
+}
+
+func TestConditionsFromStruct(t *testing.T) {
+	type Paging struct {
+		Status *string `filter:"status"`
+	}
+	type PeopleFilter struct {
+		Paging
+		MinAge int     `filter:"age,gte"`
+		Name   string  `filter:"full_name,ilike"`
+		IDs    []int64 `filter:"id,in"`
+		Sort   string
+	}
+	active := "active"
+	tests := []struct {
+		name    string
+		filter  any
+		isError bool
+	}{
+		{
+			name:   "Conditions from Struct Postgress",
+			filter: PeopleFilter{Paging: Paging{Status: &active}, MinAge: 18, IDs: []int64{1, 2}, Sort: "id"},
+		},
+		{
+			name:   "Conditions from Empty Struct Postgress",
+			filter: &PeopleFilter{},
+		},
+		{
+			name: "Conditions from Struct with Unknown Operator",
+			filter: struct {
+				Age int `filter:"age,between"`
+			}{Age: 1},
+			isError: true,
+		},
+		{
+			name:    "Conditions from Non Struct",
+			filter:  map[string]any{"age": 1},
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := ConditionsFromStruct(tt.filter)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			query, args, err := New().WithDialect(NewPostgreSQLDialect()).Select("id").From("people").Where(conditions...).ToSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+		})
+	}
+}
+
+func TestFragment(t *testing.T) {
+	activeCustomer := NewFragment("c.status = ? AND c.deleted_at IS NULL", "active")
+	bigSpender := NewFragment("? OR c.lifetime_value > ?", activeCustomer, 10000)
+	tests := []struct {
+		name    string
+		sb      SelectBuilder
+		isError bool
+	}{
+		{
+			name: "Fragment in Where and Join Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("o.id").From("orders o").
+				JoinOn("customers c", ColumnEq("c.id", "o.customer_id"), activeCustomer).
+				Where(Gt("o.total", 100), bigSpender),
+		},
+		{
+			name: "Fragment in Having SQLServer",
+			sb: New().WithDialect(NewSQLServerDialect()).Select("c.region", "COUNT(*)").From("customers c").
+				Where(activeCustomer).GroupBy("c.region").
+				Having(NewFragment("COUNT(*) > ?", 5)),
+		},
+		{
+			name: "Fragment with Missing Args Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("customers").
+				Where(NewFragment("a = ? AND b = ?", 1)),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.sb.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("should return error")
+			} else {
+				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+			}
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	hasTenant := func(walker interface {
+		WalkWhere(fn func(node ConditionNode) bool)
+	}) bool {
+		found := false
+		walker.WalkWhere(func(node ConditionNode) bool {
+			// Only a top-level filter or one inside AND groups restricts every row
+			if node.Operator == "OR" {
+				return false
+			}
+			if node.HasColumn("tenant_id") && node.Operator == "=" {
+				found = true
+			}
+			return true
+		})
+		return found
+	}
+
+	tests := []struct {
+		name   string
+		walker interface {
+			WalkWhere(fn func(node ConditionNode) bool)
+		}
+		hasTenant bool
+	}{
+		{
+			name: "Walk Select with Tenant in And Group",
+			walker: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("invoices").
+				Where(And(Eq("tenant_id", 7), Gt("total", 100)), In("status", "open", "paid")),
+			hasTenant: true,
+		},
+		{
+			name: "Walk Select with Tenant only in Or Group",
+			walker: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("invoices").
+				Where(Or(Eq("tenant_id", 7), Eq("public", true))),
+			hasTenant: false,
+		},
+		{
+			name:      "Walk Delete with Tenant in Fragment",
+			walker:    New().WithDialect(NewMySQLDialect()).Delete("invoices").Where(NewFragment("? AND paid = 1", Eq("tenant_id", 7))),
+			hasTenant: true,
+		},
+		{
+			name:      "Walk Update without Tenant",
+			walker:    New().WithDialect(NewMySQLDialect()).Update("invoices").Set("paid", 1).Where(Eq("id", 3)),
+			hasTenant: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasTenant(tt.walker); got != tt.hasTenant {
+				t.Errorf("tenant filter found = %v, want %v", got, tt.hasTenant)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	// Pin every tenant filter to the caller's tenant and drop debug filters
+	scope := func(node ConditionNode) Condition {
+		switch {
+		case node.HasColumn("tenant_id") && node.Operator == "=":
+			return Eq("tenant_id", 7)
+		case node.HasColumn("debug"):
+			return nil
+		}
+		return node.Condition
+	}
+
+	original := And(Eq("tenant_id", 1), Not(Eq("debug", true)))
+	tests := []struct {
+		name     string
+		sb       SQLBuilder
+		expected string
+		args     []any
+	}{
+		{
+			name: "Rewrite Select Where Postgress",
+			sb: New().WithDialect(NewPostgreSQLDialect()).Select("id").From("invoices").
+				Where(original, Or(Gt("total", 100), Eq("debug", true))).RewriteWhere(scope),
+			expected: `SELECT id FROM "invoices" WHERE tenant_id = $1 AND total > $2`,
+			args:     []any{7, 100},
+		},
+		{
+			name: "Rewrite Delete Fragment MySQL",
+			sb: New().WithDialect(NewMySQLDialect()).Delete("invoices").
+				Where(NewFragment("? AND paid = 1", Eq("tenant_id", 1))).RewriteWhere(scope),
+			expected: "DELETE FROM `invoices` WHERE (tenant_id = ? AND paid = 1)",
+			args:     []any{7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.sb.ToSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected || !slices.Equal(args, tt.args) {
+				t.Errorf("expected %q %v, got %q %v", tt.expected, tt.args, query, args)
+			}
+		})
+	}
+
+	// The original tree is left as it was
+	query, args := original.ToSQL(NewPostgreSQLDialect(), new(int))
+	if query != "(tenant_id = $1 AND NOT (debug = $2))" || args[0] != 1 {
+		t.Errorf("original condition changed: %q %v", query, args)
+	}
+}
+
+func TestInsertBuildBatches(t *testing.T) {
+	rows := make([][]any, 0, 1000)
+	for i := range 1000 {
+		rows = append(rows, []any{i, "name", UnsafeRaw("CURRENT_TIMESTAMP")})
+	}
+	tests := []struct {
+		name      string
+		ib        InsertBuilder
+		maxParams int
+		batches   int
+		isError   bool
+	}{
+		{
+			name:      "Build Batches Postgress",
+			ib:        New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full_name", "created_at").ValuesRows(rows),
+			maxParams: 500,
+			batches:   4,
+		},
+		{
+			name:    "Build Batches with Dialect Limit SQLServer",
+			ib:      New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("id", "full_name", "created_at").ValuesRows(rows),
+			batches: 1,
+		},
+		{
+			name: "Build Batches with On Conflict Postgress",
+			ib: New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full_name", "created_at").ValuesRows(rows[:5]).
+				OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name"), "age": 1}}),
+			maxParams: 5,
+			batches:   3,
+		},
+		{
+			name:      "Build Batches from Select MySQL",
+			ib:        New().WithDialect(NewMySQLDialect()).Insert("people").FromSelect(New().WithDialect(NewMySQLDialect()).Select("id").From("staging")),
+			maxParams: 10,
+			batches:   1,
+		},
+		{
+			name:      "Build Batches with Row over Limit SQLite",
+			ib:        New().WithDialect(NewSQLiteDialect()).Insert("people").Columns("id", "full_name", "created_at").ValuesRows(rows[:2]),
+			maxParams: 1,
+			isError:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, err := tt.ib.BuildBatches(tt.maxParams)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queries) != tt.batches {
+				t.Errorf("expected %d batches, got %d", tt.batches, len(queries))
+			}
+			for _, q := range queries {
+				if tt.maxParams > 0 && len(q.Args) > tt.maxParams {
+					t.Errorf("batch binds %d parameters, more than %d", len(q.Args), tt.maxParams)
+				}
+			}
+			t.Logf("first query ===> %.120s  ====> batches =====> %d", queries[0].SQL, len(queries))
+		})
+	}
+}
+
+func TestDeleteBuildChunks(t *testing.T) {
+	keys := make([]any, 0, 2500)
+	for i := range 2500 {
+		keys = append(keys, i)
+	}
+	tests := []struct {
+		name      string
+		db        DeleteBuilder
+		keys      []any
+		chunkSize int
+		chunks    int
+		whereArgs int
+		isError   bool
+	}{
+		{
+			name:      "Build Chunks Postgress",
+			db:        New().WithDialect(NewPostgreSQLDialect()).Delete("sessions").Where(Eq("tenant_id", 7)),
+			keys:      keys,
+			chunkSize: 500,
+			chunks:    5,
+			whereArgs: 1,
+		},
+		{
+			name:   "Build Chunks with default Size MySQL",
+			db:     New().WithDialect(NewMySQLDialect()).Delete("sessions"),
+			keys:   keys,
+			chunks: 3,
+		},
+		{
+			name:      "Build Chunks over Oracle Limit",
+			db:        New().WithDialect(NewOracleDialect()).Delete("sessions"),
+			keys:      keys,
+			chunkSize: 5000,
+			chunks:    3,
+		},
+		{
+			name:      "Build Chunks over Parameter Limit SQLServer",
+			db:        New().WithDialect(NewSQLServerDialect()).Delete("sessions").Where(Eq("tenant_id", 7), Eq("expired", true)),
+			keys:      keys,
+			chunkSize: 3000,
+			chunks:    2,
+			whereArgs: 2,
+		},
+		{
+			name:    "Build Chunks without Keys SQLite",
+			db:      New().WithDialect(NewSQLiteDialect()).Delete("sessions"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, err := tt.db.BuildChunks("id", tt.keys, tt.chunkSize)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(queries) != tt.chunks {
+				t.Errorf("expected %d chunks, got %d", tt.chunks, len(queries))
+			}
+			total := 0
+			for _, q := range queries {
+				total += len(q.Args)
+				if len(q.Args) > 2100 {
+					t.Errorf("chunk binds %d parameters, more than SQL Server allows", len(q.Args))
+				}
+			}
+			if want := len(tt.keys) + len(queries)*tt.whereArgs; total != want {
+				t.Errorf("expected %d arguments in total, got %d", want, total)
+			}
+			t.Logf("query ===> %.80s...  ====> arguments =====> %d", queries[0].SQL, len(queries[0].Args))
+		})
+	}
+}
+
+func TestInsertCloneConcurrent(t *testing.T) {
+	template := New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("id", "full_name").
+		OnConflict(ConflictAction{Target: "id", DoUpdate: map[string]any{"full_name": Excluded("full_name")}})
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			query, args, err := template.Clone().Values(i, "Arif").Values(i+100, "Budi").ToSQL()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(args) != 4 || args[0] != i {
+				t.Errorf("unexpected arguments %+v for %s", args, query)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, _, err := template.ToSQL(); err == nil {
+		t.Error("template without values should return error")
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	tests := []struct {
+		name    string
+		ub      UpsertBuilder
+		isError bool
+	}{
+		{
+			name: "Upsert Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Upsert("people").Columns("id", "full_name", "age").
+				Values(1, "Arif", 10).Key("id").Update("full_name", "age").Returning("id"),
+		},
+		{
+			name: "Upsert MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Upsert("people").Columns("id", "full_name", "age").
+				Values(1, "Arif", 10).Values(2, "Budi", 20).Key("id").Update("full_name", "age"),
+		},
+		{
+			name: "Upsert Do Nothing MySQL",
+			ub: New().WithDialect(NewMySQLDialect()).Upsert("memberships").Columns("user_id", "group_id").
+				Values(1, 2).Key("user_id", "group_id"),
+		},
+		{
+			name: "Upsert SQLServer",
+			ub: New().WithDialect(NewSQLServerDialect()).Upsert("people").Columns("id", "tenant_id", "full_name").
+				Values(1, 7, "Arif").Key("id", "tenant_id").Update("full_name"),
+		},
+		{
+			name: "Upsert Oracle",
+			ub: New().WithDialect(NewOracleDialect()).Upsert("people").Columns("id", "full_name").
+				Values(1, "Arif").Key("id").Update("full_name"),
+		},
+		{
+			name: "Upsert without Key SQLite",
+			ub: New().WithDialect(NewSQLiteDialect()).Upsert("people").Columns("id", "full_name").
+				Values(1, "Arif").Update("full_name"),
+			isError: true,
+		},
+		{
+			name: "Upsert updating Key Postgress",
+			ub: New().WithDialect(NewPostgreSQLDialect()).Upsert("people").Columns("id", "full_name").
+				Values(1, "Arif").Key("id").Update("id", "full_name"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.ub.ToSQL()
+			if tt.isError && err == nil {
+				t.Error("should return error")
+			} else {
+				t.Logf("query ===> %s  ====> arguments =====> %+v", query, args)
+			}
+		})
+	}
+}
+
+// fakeConnector opens connections that record the last statement and
+// answer every insert with the generated key 42. Statements affect one
+// row unless stale is set.
+type fakeConnector struct {
+	last  *string
+	stale bool
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (c fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	last  *string
+	stale bool
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error)      { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                             { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                { return nil, errors.New("not supported") }
+func (c fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	*c.last = query
+	for _, arg := range args {
+		if out, ok := arg.Value.(sql.Out); ok {
+			*out.Dest.(*int64) = 42
+		}
+	}
+	return fakeResult{stale: c.stale}, nil
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	*c.last = query
+	return &fakeRows{}, nil
+}
+
+type fakeResult struct{ stale bool }
+
+func (fakeResult) LastInsertId() (int64, error) { return 42, nil }
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	if r.stale {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+type fakeRows struct{ done bool }
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(42)
+	return nil
+}
+
+func TestExecReturningID(t *testing.T) {
+	var last string
+	db := sql.OpenDB(fakeConnector{last: &last})
+	defer db.Close()
+
+	tests := []struct {
+		name    string
+		ib      InsertBuilder
+		isError bool
+	}{
+		{
+			name: "Exec Returning ID Postgress",
+			ib:   New().WithDialect(NewPostgreSQLDialect()).Insert("people").Columns("full_name").Values("Arif"),
+		},
+		{
+			name: "Exec Returning ID SQLServer",
+			ib:   New().WithDialect(NewSQLServerDialect()).Insert("people").Columns("full_name").Values("Arif").Returning("person_id"),
+		},
+		{
+			name: "Exec Returning ID MySQL",
+			ib:   New().WithDialect(NewMySQLDialect()).Insert("people").Columns("full_name").Values("Arif").Returning("id"),
+		},
+		{
+			name: "Exec Returning ID Oracle",
+			ib:   New().WithDialect(NewOracleDialect()).Insert("people").Columns("full_name").Values("Arif"),
+		},
+		{
+			name:    "Exec Returning ID multiple Rows Oracle",
+			ib:      New().WithDialect(NewOracleDialect()).Insert("people").Columns("full_name").Values("Arif").Values("Budi"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.ib.ExecReturningID(context.Background(), db)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != 42 {
+				t.Errorf("expected id 42, got %d", id)
+			}
+			t.Logf("query ===> %s  ====> id =====> %d", last, id)
+		})
+	}
+}
+
+func TestUpdateWithVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		stale bool
+		err   error
+	}{
+		{name: "Update with Version Postgress"},
+		{name: "Update with stale Version Postgress", stale: true, err: ErrStaleRow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var last string
+			db := sql.OpenDB(fakeConnector{last: &last, stale: tt.stale})
+			defer db.Close()
+
+			_, err := New().WithDialect(NewPostgreSQLDialect()).Update("people").
+				Set("age", 11).WithVersion("version", 3).Where(Eq("id", 1)).
+				Exec(context.Background(), db)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			expected := `UPDATE "people" SET age = $1, version = version + 1 WHERE version = $2 AND id = $3`
+			if last != expected {
+				t.Errorf("expected %q, got %q", expected, last)
+			}
+			t.Logf("query ===> %s  ====> error =====> %v", last, err)
+		})
+	}
+}
+
+type updatedPerson struct {
+	ID   int64  `db:"id"`
+	Name string `db:"full_name"`
+}
+
+func TestUpdateExecReturning(t *testing.T) {
+	var last string
+	db := sql.OpenDB(fakeConnector{last: &last})
+	defer db.Close()
+
+	update := func(dialect Dialect) UpdateBuilder {
+		return New().WithDialect(dialect).Update("people").Set("age", 11).Where(Eq("id", 42))
+	}
+	var (
+		person   updatedPerson
+		people   []updatedPerson
+		pointers []*updatedPerson
+	)
+	tests := []struct {
+		name    string
+		ub      UpdateBuilder
+		dest    any
+		isError bool
+	}{
+		{
+			name: "Exec Returning into Struct Postgress",
+			ub:   update(NewPostgreSQLDialect()).Returning("id"),
+			dest: &person,
+		},
+		{
+			name: "Exec Returning into Slice SQLServer",
+			ub:   update(NewSQLServerDialect()).Returning("id"),
+			dest: &people,
+		},
+		{
+			name: "Exec Returning into Pointer Slice SQLite",
+			ub:   update(NewSQLiteDialect()).Returning("id"),
+			dest: &pointers,
+		},
+		{
+			name:    "Exec Returning without Returning Postgress",
+			ub:      update(NewPostgreSQLDialect()),
+			dest:    &person,
+			isError: true,
+		},
+		{
+			name:    "Exec Returning MySQL",
+			ub:      update(NewMySQLDialect()).Returning("id"),
+			dest:    &person,
+			isError: true,
+		},
+		{
+			name:    "Exec Returning into non Pointer Postgress",
+			ub:      update(NewPostgreSQLDialect()).Returning("id"),
+			dest:    person,
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ub.ExecReturning(context.Background(), db, tt.dest)
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("query ===> %s  ====> dest =====> %+v", last, tt.dest)
+		})
+	}
+	if person.ID != 42 || len(people) != 1 || people[0].ID != 42 || len(pointers) != 1 || pointers[0].ID != 42 {
+		t.Errorf("unexpected scan results: %+v %+v %+v", person, people, pointers)
+	}
+}
+
+func TestCreateIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		cb       CreateIndexBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Create Index Postgress",
+			cb: New().WithDialect(NewPostgreSQLDialect()).CreateIndex("people_email_key").
+				On("people", "LOWER(email)").Unique().Where(Expr("deleted_at IS NULL")).Concurrently(),
+			expected: `CREATE UNIQUE INDEX CONCURRENTLY people_email_key ON "people" ((LOWER(email))) WHERE (deleted_at IS NULL)`,
+		},
+		{
+			name:     "Create Index with Method Postgress",
+			cb:       New().WithDialect(NewPostgreSQLDialect()).CreateIndex("docs_tags_idx").On("docs", "tags").Using("gin"),
+			expected: `CREATE INDEX docs_tags_idx ON "docs" USING gin (tags)`,
+		},
+		{
+			name: "Create Index MySQL",
+			cb: New().WithDialect(NewMySQLDialect()).CreateIndex("orders_customer_idx").
+				On("orders", "customer_id", "created_at DESC").Using("btree").Concurrently(),
+			expected: "CREATE INDEX orders_customer_idx USING BTREE ON `orders` (customer_id, created_at DESC) ALGORITHM=INPLACE LOCK=NONE",
+		},
+		{
+			name: "Create Index SQLServer",
+			cb: New().WithDialect(NewSQLServerDialect()).CreateIndex("orders_open_idx").
+				On("orders", "customer_id").Where(Expr("status = 'open'")).Concurrently(),
+			expected: "CREATE INDEX orders_open_idx ON [orders] (customer_id) WHERE (status = 'open') WITH (ONLINE = ON)",
+		},
+		{
+			name:     "Create Index Oracle",
+			cb:       New().WithDialect(NewOracleDialect()).CreateIndex("people_name_idx").On("people", "UPPER(full_name) DESC").Concurrently(),
+			expected: "CREATE INDEX people_name_idx ON people (UPPER(full_name) DESC) ONLINE",
+		},
+		{
+			name:     "Create Index SQLite",
+			cb:       New().WithDialect(NewSQLiteDialect()).CreateIndex("people_email_idx").On("people", "lower(email) DESC"),
+			expected: `CREATE INDEX people_email_idx ON "people" ((lower(email)) DESC)`,
+		},
+		{
+			name:    "Create Index with bound Predicate Postgress",
+			cb:      New().WithDialect(NewPostgreSQLDialect()).CreateIndex("orders_open_idx").On("orders", "id").Where(Eq("status", "open")),
+			isError: true,
+		},
+		{
+			name:    "Create partial Index MySQL",
+			cb:      New().WithDialect(NewMySQLDialect()).CreateIndex("orders_open_idx").On("orders", "id").Where(Expr("status = 'open'")),
+			isError: true,
+		},
+		{
+			name:    "Create expression Index SQLServer",
+			cb:      New().WithDialect(NewSQLServerDialect()).CreateIndex("people_email_idx").On("people", "LOWER(email)"),
+			isError: true,
+		},
+		{
+			name:    "Create Index without Columns Postgress",
+			cb:      New().WithDialect(NewPostgreSQLDialect()).CreateIndex("people_idx").On("people"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.cb.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestAlterTableConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		ab       AlterTableBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Add Constraints Postgress",
+			ab: New().WithDialect(NewPostgreSQLDialect()).AlterTable("orders").
+				AddConstraint("orders_customer_fk", ForeignKey("customer_id").References("customers", "id").OnDelete(Cascade).OnUpdate(Restrict)).
+				AddConstraint("orders_number_key", Unique("tenant_id", "number")).
+				AddConstraint("orders_total_check", Check(Expr("total >= 0"))),
+			expected: `ALTER TABLE "orders" ADD CONSTRAINT orders_customer_fk FOREIGN KEY (customer_id) REFERENCES "customers" (id) ON DELETE CASCADE ON UPDATE RESTRICT, ` +
+				"ADD CONSTRAINT orders_number_key UNIQUE (tenant_id, number), ADD CONSTRAINT orders_total_check CHECK ((total >= 0))",
+		},
+		{
+			name: "Add Foreign Key SQLServer",
+			ab: New().WithDialect(NewSQLServerDialect()).AlterTable("orders").
+				AddConstraint("orders_customer_fk", ForeignKey("customer_id").References("customers", "id").OnDelete(SetNull)),
+			expected: "ALTER TABLE [orders] ADD CONSTRAINT orders_customer_fk FOREIGN KEY (customer_id) REFERENCES [customers] (id) ON DELETE SET NULL",
+		},
+		{
+			name:     "Drop Constraint MySQL",
+			ab:       New().WithDialect(NewMySQLDialect()).AlterTable("orders").DropConstraint("orders_customer_fk"),
+			expected: "ALTER TABLE `orders` DROP CONSTRAINT orders_customer_fk",
+		},
+		{
+			name: "Add Foreign Key On Update Oracle",
+			ab: New().WithDialect(NewOracleDialect()).AlterTable("orders").
+				AddConstraint("orders_customer_fk", ForeignKey("customer_id").References("customers", "id").OnUpdate(Cascade)),
+			isError: true,
+		},
+		{
+			name: "Add Foreign Key with mismatched Columns Postgress",
+			ab: New().WithDialect(NewPostgreSQLDialect()).AlterTable("orders").
+				AddConstraint("orders_customer_fk", ForeignKey("customer_id", "tenant_id").References("customers", "id")),
+			isError: true,
+		},
+		{
+			name:    "Add Check with bound Args Postgress",
+			ab:      New().WithDialect(NewPostgreSQLDialect()).AlterTable("orders").AddConstraint("orders_total_check", Check(Gt("total", 0))),
+			isError: true,
+		},
+		{
+			name: "Add several Constraints SQLServer",
+			ab: New().WithDialect(NewSQLServerDialect()).AlterTable("orders").
+				AddConstraint("orders_number_key", Unique("number")).DropConstraint("orders_old_key"),
+			isError: true,
+		},
+		{
+			name:    "Add Constraint SQLite",
+			ab:      New().WithDialect(NewSQLiteDialect()).AlterTable("orders").AddConstraint("orders_number_key", Unique("number")),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.ab.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestAlterTableColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		ab       AlterTableBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Add and Drop Columns Postgress",
+			ab: New().WithDialect(NewPostgreSQLDialect()).AlterTable("users").
+				AddColumn("nickname", "VARCHAR(50) DEFAULT '' NOT NULL").DropColumn("legacy_id"),
+			expected: `ALTER TABLE "users" ADD COLUMN nickname VARCHAR(50) DEFAULT '' NOT NULL, DROP COLUMN legacy_id`,
+		},
+		{
+			name:     "Alter Column Postgress",
+			ab:       New().WithDialect(NewPostgreSQLDialect()).AlterTable("users").AlterColumn("email", "TEXT", false),
+			expected: `ALTER TABLE "users" ALTER COLUMN email TYPE TEXT, ALTER COLUMN email SET NOT NULL`,
+		},
+		{
+			name:     "Alter Column MySQL",
+			ab:       New().WithDialect(NewMySQLDialect()).AlterTable("users").AlterColumn("email", "TEXT", true),
+			expected: "ALTER TABLE `users` MODIFY COLUMN email TEXT NULL",
+		},
+		{
+			name:     "Add Column SQLServer",
+			ab:       New().WithDialect(NewSQLServerDialect()).AlterTable("users").AddColumn("nickname", "NVARCHAR(50) NULL"),
+			expected: "ALTER TABLE [users] ADD nickname NVARCHAR(50) NULL",
+		},
+		{
+			name:     "Alter Column Oracle",
+			ab:       New().WithDialect(NewOracleDialect()).AlterTable("users").AlterColumn("email", "VARCHAR2(255)", false),
+			expected: "ALTER TABLE users MODIFY (email VARCHAR2(255) NOT NULL)",
+		},
+		{
+			name:     "Drop Column SQLite",
+			ab:       New().WithDialect(NewSQLiteDialect()).AlterTable("users").DropColumn("legacy_id"),
+			expected: `ALTER TABLE "users" DROP COLUMN legacy_id`,
+		},
+		{
+			name:    "Alter Column SQLite",
+			ab:      New().WithDialect(NewSQLiteDialect()).AlterTable("users").AlterColumn("email", "TEXT", true),
+			isError: true,
+		},
+		{
+			name:    "Add Column without Definition MySQL",
+			ab:      New().WithDialect(NewMySQLDialect()).AlterTable("users").AddColumn("nickname", ""),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.ab.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		cb       CreateTableBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name: "Create Table Postgress",
+			cb: New().WithDialect(NewPostgreSQLDialect()).CreateTable("orders").IfNotExists().
+				Column("id", "BIGINT NOT NULL").
+				Column("customer_id", "BIGINT NULL").
+				PrimaryKey("id").
+				Constraint("orders_customer_fk", ForeignKey("customer_id").References("customers", "id").OnDelete(SetNull)),
+			expected: `CREATE TABLE IF NOT EXISTS "orders" (id BIGINT NOT NULL, customer_id BIGINT NULL, PRIMARY KEY (id), ` +
+				`CONSTRAINT orders_customer_fk FOREIGN KEY (customer_id) REFERENCES "customers" (id) ON DELETE SET NULL)`,
+		},
+		{
+			name: "Create Table with unnamed Constraint SQLite",
+			cb: New().WithDialect(NewSQLiteDialect()).CreateTable("orders").
+				Column("number", "TEXT NOT NULL").Constraint("", Unique("number")),
+			expected: `CREATE TABLE "orders" (number TEXT NOT NULL, UNIQUE (number))`,
+		},
+		{
+			name:    "Create Table If Not Exists Oracle",
+			cb:      New().WithDialect(NewOracleDialect()).CreateTable("orders").IfNotExists().Column("id", "NUMBER(19) NOT NULL"),
+			isError: true,
+		},
+		{
+			name:    "Create Table without Columns MySQL",
+			cb:      New().WithDialect(NewMySQLDialect()).CreateTable("orders"),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.cb.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestDrop(t *testing.T) {
+	tests := []struct {
+		name     string
+		db       DropBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "Drop Table Postgress",
+			db:       New().WithDialect(NewPostgreSQLDialect()).DropTable("people").IfExists().Cascade(),
+			expected: `DROP TABLE IF EXISTS "people" CASCADE`,
+		},
+		{
+			name:     "Drop Table Oracle",
+			db:       New().WithDialect(NewOracleDialect()).DropTable("people").Cascade(),
+			expected: "DROP TABLE people CASCADE CONSTRAINTS",
+		},
+		{
+			name:     "Drop Index MySQL",
+			db:       New().WithDialect(NewMySQLDialect()).DropIndex("people_email_idx", "people"),
+			expected: "DROP INDEX people_email_idx ON `people`",
+		},
+		{
+			name:     "Drop Index SQLServer",
+			db:       New().WithDialect(NewSQLServerDialect()).DropIndex("people_email_idx", "people").IfExists(),
+			expected: "DROP INDEX IF EXISTS people_email_idx ON [people]",
+		},
+		{
+			name:     "Drop Index SQLite",
+			db:       New().WithDialect(NewSQLiteDialect()).DropIndex("people_email_idx", "people").IfExists(),
+			expected: "DROP INDEX IF EXISTS people_email_idx",
+		},
+		{
+			name:     "Drop View MySQL",
+			db:       New().WithDialect(NewMySQLDialect()).DropView("active_people").IfExists(),
+			expected: "DROP VIEW IF EXISTS active_people",
+		},
+		{
+			name:    "Drop Index If Exists MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).DropIndex("people_email_idx", "people").IfExists(),
+			isError: true,
+		},
+		{
+			name:    "Drop Index without Table MySQL",
+			db:      New().WithDialect(NewMySQLDialect()).DropIndex("people_email_idx", ""),
+			isError: true,
+		},
+		{
+			name:    "Drop Table If Exists Oracle",
+			db:      New().WithDialect(NewOracleDialect()).DropTable("people").IfExists(),
+			isError: true,
+		},
+		{
+			name:    "Drop View Cascade SQLServer",
+			db:      New().WithDialect(NewSQLServerDialect()).DropView("active_people").Cascade(),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.db.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tb       TruncateBuilder
+		expected string
+		isError  bool
+	}{
+		{
+			name:     "Truncate Postgress",
+			tb:       New().WithDialect(NewPostgreSQLDialect()).Truncate("logs").RestartIdentity().Cascade(),
+			expected: `TRUNCATE TABLE "logs" RESTART IDENTITY CASCADE`,
+		},
+		{
+			name:     "Truncate MySQL",
+			tb:       New().WithDialect(NewMySQLDialect()).Truncate("logs").RestartIdentity(),
+			expected: "TRUNCATE TABLE `logs`",
+		},
+		{
+			name:     "Truncate Oracle",
+			tb:       New().WithDialect(NewOracleDialect()).Truncate("logs").Cascade(),
+			expected: "TRUNCATE TABLE logs CASCADE",
+		},
+		{
+			name:     "Truncate SQLite",
+			tb:       New().WithDialect(NewSQLiteDialect()).Truncate("logs"),
+			expected: `DELETE FROM "logs"`,
+		},
+		{
+			name:    "Truncate with Cascade SQLServer",
+			tb:      New().WithDialect(NewSQLServerDialect()).Truncate("logs").Cascade(),
+			isError: true,
+		},
+		{
+			name:    "Truncate with Restart Identity Oracle",
+			tb:      New().WithDialect(NewOracleDialect()).Truncate("logs").RestartIdentity(),
+			isError: true,
+		},
+		{
+			name:    "Truncate with Restart Identity SQLite",
+			tb:      New().WithDialect(NewSQLiteDialect()).Truncate("logs").RestartIdentity(),
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.tb.ToSQL()
+			if tt.isError {
+				if err == nil {
+					t.Error("should return error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestUpdateSetValuesOrder(t *testing.T) {
+	values := map[string]any{"occupation": "Engineer", "age": 30, "full_name": "Arif", "city": "Jakarta"}
+	want := `UPDATE "people" SET age = $1, city = $2, full_name = $3, occupation = $4 WHERE id = $5`
+	for range 20 {
+		query, args, err := New().WithDialect(NewPostgreSQLDialect()).Update("people").SetValues(values).Where(Eq("id", 1)).ToSQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if query != want || args[0] != 30 || args[3] != "Engineer" {
+			t.Fatalf("unstable output: %s %+v", query, args)
+		}
+	}
+}
+
+func TestTableEscaping(t *testing.T) {
+	qb := New().WithDialect(NewPostgreSQLDialect())
+	for _, sb := range []SQLBuilder{
+		qb.CreateTable("SchemaMigrations").Column("version", "BIGINT NOT NULL"),
+		qb.Insert("SchemaMigrations").Columns("version").Values(1),
+		qb.Select("version").From("SchemaMigrations"),
+		qb.Update("SchemaMigrations").Set("version", 2).Where(Eq("version", 1)),
+		qb.Delete("SchemaMigrations").Where(Eq("version", 1)),
+		qb.Truncate("SchemaMigrations"),
+	} {
+		query, _, err := sb.ToSQL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(query, `"SchemaMigrations"`) {
+			t.Errorf("expected the table to be escaped, got %q", query)
+		}
 	}
 }
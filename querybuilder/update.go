@@ -1,8 +1,13 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 // UpdateBuilder interface for constructing UPDATE queries
@@ -10,12 +15,34 @@ type UpdateBuilder interface {
 	Table(table string) UpdateBuilder
 	Set(column string, value interface{}) UpdateBuilder
 	SetRaw(column string, expression string) UpdateBuilder
+	SetColumn(column, source string) UpdateBuilder
+	SetIf(ok bool, column string, value any) UpdateBuilder
+	Incr(column string, by any) UpdateBuilder
+	Decr(column string, by any) UpdateBuilder
+	From(table string) UpdateBuilder
+	With(alias string, builder SQLBuilder) UpdateBuilder
+	WithRecursive(alias string, builder SQLBuilder) UpdateBuilder
+	Join(table, on string) UpdateBuilder
+	LeftJoin(table, on string) UpdateBuilder
 	Where(conditions ...Condition) UpdateBuilder
+	WhereIf(ok bool, conditions ...Condition) UpdateBuilder
+	WhereGroup(fn func(g ConditionGroup)) UpdateBuilder
+	WalkWhere(fn func(node ConditionNode) bool)
+	RewriteWhere(fn func(node ConditionNode) Condition) UpdateBuilder
 	OrderBy(column string, direction string) UpdateBuilder
 	Limit(limit int) UpdateBuilder
 	Returning(columns ...string) UpdateBuilder
 	ToSQL() (string, []interface{}, error)
+	Clone() UpdateBuilder
+	Comment(text string) UpdateBuilder
+	Prefix(sql string, args ...any) UpdateBuilder
+	Suffix(sql string, args ...any) UpdateBuilder
 	SetValues(values map[string]any) UpdateBuilder
+	SetStruct(v any, opts ...SetStructOption) UpdateBuilder
+	WithVersion(column string, current any) UpdateBuilder
+	Exec(ctx context.Context, db Queryer) (sql.Result, error)
+	ExecReturning(ctx context.Context, db Queryer, dest any) error
+	AllRows() UpdateBuilder
 }
 
 // updateBuilder implements UpdateBuilder
@@ -23,11 +50,25 @@ type updateBuilder struct {
 	dialect    Dialect
 	table      string
 	sets       []setClause
+	from       string
+	ctes       []cte
+	joins      []join
 	where      []Condition
 	orderBy    []order
 	limit      *int
 	returning  []string
 	paramCount int
+	comments   []string
+	prefixes   []rawClause
+	suffixes   []rawClause
+	timestamps *timestampColumns
+	version    string // optimistic locking column set by WithVersion
+	safe       bool   // reject updates without a WHERE clause
+	allRows    bool   // AllRows was called, lifting the safe mode check
+	err        error  // first error raised while chaining, returned by ToSQL
+
+	// stampsFor resolves timestamps again when Table names the table
+	stampsFor func(table string) *timestampColumns
 }
 
 type setClause struct {
@@ -39,14 +80,20 @@ type setClause struct {
 // NewUpdateBuilder creates a new UpdateBuilder instance
 func (qb *QueryBuilder) NewUpdateBuilder() UpdateBuilder {
 	return &updateBuilder{
-		dialect: qb.dialect,
-		sets:    make([]setClause, 0),
+		dialect:    qb.dialect,
+		sets:       make([]setClause, 0),
+		timestamps: qb.timestamps,
+		stampsFor:  qb.updateTimestamps,
+		safe:       qb.safeUpdates,
 	}
 }
 
 // Table specifies the table to update
 func (ub *updateBuilder) Table(table string) UpdateBuilder {
 	ub.table = table
+	if ub.stampsFor != nil {
+		ub.timestamps = ub.stampsFor(table)
+	}
 	return ub
 }
 
@@ -70,12 +117,158 @@ func (ub *updateBuilder) SetRaw(column string, expression string) UpdateBuilder
 	return ub
 }
 
-// SetValues sets multiple column-value pairs to update
+// SetColumn assigns another column to a column, typically across the
+// tables of a multi-table update: SetColumn("o.discount", "c.discount")
+func (ub *updateBuilder) SetColumn(column, source string) UpdateBuilder {
+	if !isColumnRef(source) {
+		if ub.err == nil {
+			ub.err = errors.New("invalid column reference " + source)
+		}
+		return ub
+	}
+	return ub.SetRaw(column, source)
+}
+
+// isColumnRef reports whether s is a possibly qualified column name
+func isColumnRef(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if part == "" {
+			return false
+		}
+		for i, r := range part {
+			if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SetIf adds a column-value pair to update only when ok is true
+func (ub *updateBuilder) SetIf(ok bool, column string, value any) UpdateBuilder {
+	if !ok {
+		return ub
+	}
+	return ub.Set(column, value)
+}
+
+// Incr increments a column by a bound amount: column = column + ?
+func (ub *updateBuilder) Incr(column string, by any) UpdateBuilder {
+	return ub.Set(column, ExprValue(column+" + ?", by))
+}
+
+// Decr decrements a column by a bound amount: column = column - ?
+func (ub *updateBuilder) Decr(column string, by any) UpdateBuilder {
+	return ub.Set(column, ExprValue(column+" - ?", by))
+}
+
+// From adds a table the update reads from, rendered as UPDATE ... FROM on
+// PostgreSQL, SQLite and SQL Server and as a multi-table UPDATE on MySQL
+func (ub *updateBuilder) From(table string) UpdateBuilder {
+	ub.from = table
+	return ub
+}
+
+// With adds a common table expression to the statement, e.g.
+// WITH todo AS (SELECT id ... LIMIT 100) UPDATE t SET ... FROM todo ... for
+// batched updates. Oracle does not support WITH on UPDATE.
+func (ub *updateBuilder) With(alias string, builder SQLBuilder) UpdateBuilder {
+	ub.ctes = append(ub.ctes, cte{alias: alias, builder: builder})
+	return ub
+}
+
+// WithRecursive adds a recursive common table expression to the statement
+func (ub *updateBuilder) WithRecursive(alias string, builder SQLBuilder) UpdateBuilder {
+	ub.ctes = append(ub.ctes, cte{alias: alias, builder: builder, recursive: true})
+	return ub
+}
+
+// Join adds an INNER JOIN to the update. MySQL joins the updated table
+// directly; the other dialects join the From table, which SQL Server
+// defaults to the updated table.
+func (ub *updateBuilder) Join(table, on string) UpdateBuilder {
+	ub.joins = append(ub.joins, join{joinType: "INNER", table: table, condition: on})
+	return ub
+}
+
+// LeftJoin adds a LEFT JOIN to the update, see Join
+func (ub *updateBuilder) LeftJoin(table, on string) UpdateBuilder {
+	ub.joins = append(ub.joins, join{joinType: "LEFT", table: table, condition: on})
+	return ub
+}
+
+// validateFrom checks that the dialect can render the From and joins
+func (ub *updateBuilder) validateFrom() error {
+	if ub.from == "" && len(ub.joins) == 0 {
+		return nil
+	}
+	switch ub.dialect.(type) {
+	case oracleDialect:
+		return errors.New("UPDATE with FROM or JOIN is not supported by Oracle")
+	case mysqlDialect:
+		if len(ub.orderBy) > 0 || ub.limit != nil {
+			return errors.New("multi-table UPDATE cannot use ORDER BY or LIMIT")
+		}
+	case sqlserverDialect:
+	default:
+		if ub.from == "" {
+			return errors.New("UPDATE with JOIN requires From")
+		}
+	}
+	return nil
+}
+
+// updateTarget returns what follows UPDATE: the table, the joined tables on
+// MySQL, or the table alias on SQL Server when the table is joined to itself
+func (ub *updateBuilder) updateTarget() string {
+	switch ub.dialect.(type) {
+	case mysqlDialect:
+		target := escapeTableRef(ub.dialect, ub.table)
+		if ub.from != "" {
+			target += ", " + escapeTableRef(ub.dialect, ub.from)
+		}
+		return target + buildUpdateJoins(ub.dialect, ub.joins)
+	case sqlserverDialect:
+		if ub.from == "" && len(ub.joins) > 0 {
+			if fields := strings.Fields(ub.table); len(fields) > 1 {
+				return escapeIdentifier(ub.dialect, fields[len(fields)-1])
+			}
+		}
+	}
+	return escapeTableRef(ub.dialect, ub.table)
+}
+
+// buildFromClause builds the UPDATE ... FROM clause
+func (ub *updateBuilder) buildFromClause() string {
+	if _, ok := ub.dialect.(mysqlDialect); ok {
+		return ""
+	}
+	from := ub.from
+	if _, ok := ub.dialect.(sqlserverDialect); ok && from == "" && len(ub.joins) > 0 {
+		from = ub.table
+	}
+	if from == "" {
+		return ""
+	}
+	return " FROM " + escapeTableRef(ub.dialect, from) + buildUpdateJoins(ub.dialect, ub.joins)
+}
+
+// buildUpdateJoins renders the joins of an update
+func buildUpdateJoins(dialect Dialect, joins []join) string {
+	var clause strings.Builder
+	for _, j := range joins {
+		clause.WriteString(" " + j.joinType + " JOIN " + escapeTableRef(dialect, j.table) + " ON " + j.condition)
+	}
+	return clause.String()
+}
+
+// SetValues sets multiple column-value pairs to update, sorted by column
+// so the generated SQL and argument order are stable
 func (ub *updateBuilder) SetValues(values map[string]any) UpdateBuilder {
-	for col, val := range values {
+	for _, col := range slices.Sorted(maps.Keys(values)) {
 		ub.sets = append(ub.sets, setClause{
 			column: col,
-			value:  val,
+			value:  values[col],
 			isRaw:  false,
 		})
 	}
@@ -89,6 +282,35 @@ func (ub *updateBuilder) Where(conditions ...Condition) UpdateBuilder {
 	return ub
 }
 
+// WhereIf adds WHERE conditions only when ok is true
+func (ub *updateBuilder) WhereIf(ok bool, conditions ...Condition) UpdateBuilder {
+	if !ok {
+		return ub
+	}
+	return ub.Where(conditions...)
+}
+
+// WhereGroup adds the conditions collected by fn as a single WHERE
+// condition joined with AND; an empty group adds nothing
+func (ub *updateBuilder) WhereGroup(fn func(g ConditionGroup)) UpdateBuilder {
+	if cond := Group(fn); cond != nil {
+		ub.where = append(ub.where, cond)
+	}
+	return ub
+}
+
+// WalkWhere visits the WHERE conditions with Walk
+func (ub *updateBuilder) WalkWhere(fn func(node ConditionNode) bool) {
+	WalkConditions(ub.where, fn)
+}
+
+// RewriteWhere replaces the WHERE conditions with Rewrite, e.g. for
+// middleware narrowing a tenant filter
+func (ub *updateBuilder) RewriteWhere(fn func(node ConditionNode) Condition) UpdateBuilder {
+	ub.where = RewriteConditions(ub.where, fn)
+	return ub
+}
+
 // OrderBy adds ORDER BY clause
 func (ub *updateBuilder) OrderBy(column string, direction string) UpdateBuilder {
 	if direction != "ASC" && direction != "DESC" {
@@ -107,14 +329,58 @@ func (ub *updateBuilder) Limit(limit int) UpdateBuilder {
 	return ub
 }
 
+// Comment prefixes the statement with a /* ... */ comment
+func (ub *updateBuilder) Comment(text string) UpdateBuilder {
+	ub.comments = append(ub.comments, text)
+	return ub
+}
+
+// Prefix adds a raw SQL fragment with bound args before the statement
+func (ub *updateBuilder) Prefix(sql string, args ...any) UpdateBuilder {
+	ub.prefixes = append(ub.prefixes, rawClause{sql: sql, args: args})
+	return ub
+}
+
+// Suffix adds a raw SQL fragment with bound args after the statement
+func (ub *updateBuilder) Suffix(sql string, args ...any) UpdateBuilder {
+	ub.suffixes = append(ub.suffixes, rawClause{sql: sql, args: args})
+	return ub
+}
+
 // Returning specifies columns to return after update
 func (ub *updateBuilder) Returning(columns ...string) UpdateBuilder {
 	ub.returning = columns
 	return ub
 }
 
+// AllRows confirms the update is meant to touch every row, allowing it
+// without a WHERE clause when safe updates are enabled
+func (ub *updateBuilder) AllRows() UpdateBuilder {
+	ub.allRows = true
+	return ub
+}
+
+// Clone returns a deep copy of the builder that can be modified independently
+func (ub *updateBuilder) Clone() UpdateBuilder {
+	cloned := *ub
+	cloned.sets = slices.Clone(ub.sets)
+	cloned.ctes = cloneCTEs(ub.ctes)
+	cloned.joins = cloneJoins(ub.joins)
+	cloned.where = slices.Clone(ub.where)
+	cloned.orderBy = cloneOrders(ub.orderBy)
+	cloned.limit = cloneInt(ub.limit)
+	cloned.returning = slices.Clone(ub.returning)
+	cloned.comments = slices.Clone(ub.comments)
+	cloned.prefixes = cloneRawClauses(ub.prefixes)
+	cloned.suffixes = cloneRawClauses(ub.suffixes)
+	return &cloned
+}
+
 // ToSQL generates the SQL query and returns the query and parameters
 func (ub *updateBuilder) ToSQL() (string, []any, error) {
+	if ub.err != nil {
+		return "", nil, ub.err
+	}
 	if ub.table == "" {
 		return "", nil, errors.New("no table specified")
 	}
@@ -127,15 +393,55 @@ func (ub *updateBuilder) ToSQL() (string, []any, error) {
 		query strings.Builder
 		args  []interface{}
 	)
+	ub.paramCount = 0
+
+	prefixArgs := buildPrefixes(&query, ub.prefixes, ub.dialect, &ub.paramCount)
+	args = append(args, prefixArgs...)
+
+	buildComments(&query, ub.comments)
+
+	if err := ub.validateFrom(); err != nil {
+		return "", nil, err
+	}
+	if err := checkReturning(ub.dialect, ub.returning, true); err != nil {
+		return "", nil, err
+	}
+	if _, ok := ub.dialect.(oracleDialect); ok && len(ub.ctes) > 0 {
+		return "", nil, errors.New("WITH is not supported on UPDATE by Oracle")
+	}
+	withArgs, err := buildWithClause(&query, ub.ctes, ub.dialect, &ub.paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, withArgs...)
 
 	query.WriteString("UPDATE ")
-	query.WriteString(ub.table)
+	topSQL, topArgs, err := buildTopClause(ub.dialect, ub.limit, ub.orderBy, &ub.paramCount)
+	if err != nil {
+		return "", nil, err
+	}
+	query.WriteString(topSQL)
+	args = append(args, topArgs...)
+	query.WriteString(ub.updateTarget())
 
-	setClause, setArgs := ub.buildSetClause()
+	setClause, setArgs, err := ub.buildSetClause()
+	if err != nil {
+		return "", nil, err
+	}
 	query.WriteString(setClause)
 	args = append(args, setArgs...)
 
-	whereClause, whereArgs := ub.buildWhereClause()
+	query.WriteString(ub.buildOutputClause())
+
+	query.WriteString(ub.buildFromClause())
+
+	whereClause, whereArgs, err := ub.buildWhereClause()
+	if err != nil {
+		return "", nil, err
+	}
+	if whereClause == "" && ub.safe && !ub.allRows {
+		return "", nil, errors.New("update without WHERE clause; call AllRows to update every row")
+	}
 	query.WriteString(whereClause)
 	args = append(args, whereArgs...)
 
@@ -149,15 +455,18 @@ func (ub *updateBuilder) ToSQL() (string, []any, error) {
 	returningClause := ub.buildReturningClause()
 	query.WriteString(returningClause)
 
+	suffixArgs := buildSuffixes(&query, ub.suffixes, ub.dialect, &ub.paramCount)
+	args = append(args, suffixArgs...)
+
 	return query.String(), args, nil
 }
 
 // buildSetClause builds the SET clause and returns the clause and its arguments.
-func (ub *updateBuilder) buildSetClause() (string, []any) {
+func (ub *updateBuilder) buildSetClause() (string, []any, error) {
 	var clause strings.Builder
 	var args []any
 	clause.WriteString(" SET ")
-	for i, set := range ub.sets {
+	for i, set := range ub.setsWithTimestamps() {
 		if i > 0 {
 			clause.WriteString(", ")
 		}
@@ -166,21 +475,29 @@ func (ub *updateBuilder) buildSetClause() (string, []any) {
 		if set.isRaw {
 			clause.WriteString(set.value.(string))
 		} else {
-			clause.WriteString(ub.dialect.Placeholder(ub.paramCount))
-			args = append(args, set.value)
-			ub.paramCount++
+			valArgs, err := writeValue(&clause, set.value, ub.dialect, &ub.paramCount)
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, valArgs...)
 		}
 	}
-	return clause.String(), args
+	return clause.String(), args, nil
 }
 
 // buildWhereClause builds the WHERE clause and returns the clause and its arguments.
-func (ub *updateBuilder) buildWhereClause() (string, []any) {
+func (ub *updateBuilder) buildWhereClause() (string, []any, error) {
 	if len(ub.where) == 0 {
-		return "", nil
+		return "", nil, nil
+	}
+	whereSQL, whereArgs, err := buildConditions(ub.where, ub.dialect, &ub.paramCount)
+	if err != nil {
+		return "", nil, err
 	}
-	whereSQL, whereArgs := buildConditions(ub.where, ub.dialect, &ub.paramCount)
-	return " WHERE " + whereSQL, whereArgs
+	if whereSQL == "" {
+		return "", nil, nil
+	}
+	return " WHERE " + whereSQL, whereArgs, nil
 }
 
 // buildOrderByClause builds the ORDER BY clause.
@@ -201,6 +518,20 @@ func (ub *updateBuilder) buildOrderByClause() string {
 	return clause.String()
 }
 
+// buildTopClause builds the "TOP (?) " limit of SQL Server UPDATE and DELETE
+// statements, which cannot be ordered
+func buildTopClause(dialect Dialect, limit *int, orderBy []order, paramCount *int) (string, []any, error) {
+	if _, ok := dialect.(sqlserverDialect); !ok || limit == nil {
+		return "", nil, nil
+	}
+	if len(orderBy) > 0 {
+		return "", nil, errors.New("SQL Server cannot order a limited UPDATE or DELETE; select the rows in a CTE instead")
+	}
+	sql := "TOP (" + dialect.Placeholder(*paramCount) + ") "
+	*paramCount++
+	return sql, []any{*limit}, nil
+}
+
 // buildLimitClause builds the LIMIT clause and returns the clause and its arguments.
 func (ub *updateBuilder) buildLimitClause() (string, []any) {
 	if ub.limit == nil {
@@ -217,23 +548,38 @@ func (ub *updateBuilder) buildLimitClause() (string, []any) {
 	}
 }
 
+// buildOutputClause builds SQL Server's OUTPUT clause, which returns the
+// updated rows in place of RETURNING
+func (ub *updateBuilder) buildOutputClause() string {
+	if _, ok := ub.dialect.(sqlserverDialect); !ok || len(ub.returning) == 0 {
+		return ""
+	}
+	var clause strings.Builder
+	clause.WriteString(" OUTPUT ")
+	for i, col := range ub.returning {
+		if i > 0 {
+			clause.WriteString(", ")
+		}
+		if dot := strings.LastIndex(col, "."); dot >= 0 {
+			col = col[dot+1:]
+		}
+		clause.WriteString("INSERTED." + col)
+	}
+	return clause.String()
+}
+
 // buildReturningClause builds the RETURNING clause.
 func (ub *updateBuilder) buildReturningClause() string {
-	if len(ub.returning) == 0 {
+	if len(ub.returning) == 0 || !supportsReturning(ub.dialect) {
 		return ""
 	}
-	switch ub.dialect.(type) {
-	case postgresDialect, sqliteDialect:
-		var clause strings.Builder
-		clause.WriteString(" RETURNING ")
-		for i, col := range ub.returning {
-			if i > 0 {
-				clause.WriteString(", ")
-			}
-			clause.WriteString(col)
+	var clause strings.Builder
+	clause.WriteString(" RETURNING ")
+	for i, col := range ub.returning {
+		if i > 0 {
+			clause.WriteString(", ")
 		}
-		return clause.String()
-	default:
-		return ""
+		clause.WriteString(col)
 	}
+	return clause.String()
 }
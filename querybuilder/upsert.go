@@ -0,0 +1,92 @@
+package querybuilder
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// UpsertBuilder builds an insert-or-update statement for any dialect. It
+// renders INSERT ... ON CONFLICT on PostgreSQL and SQLite, INSERT ... ON
+// DUPLICATE KEY UPDATE on MySQL and MERGE on SQL Server and Oracle.
+type UpsertBuilder interface {
+	Columns(columns ...string) UpsertBuilder
+	Values(values ...any) UpsertBuilder
+	Key(columns ...string) UpsertBuilder
+	Update(columns ...string) UpsertBuilder
+	Returning(columns ...string) UpsertBuilder
+	ToSQL() (string, []any, error)
+}
+
+// upsertBuilder implements UpsertBuilder on top of insertBuilder
+type upsertBuilder struct {
+	insert  *insertBuilder
+	key     []string
+	updates []string
+}
+
+// Upsert begins an insert-or-update on table
+func (qb *QueryBuilder) Upsert(table string) UpsertBuilder {
+	return &upsertBuilder{insert: qb.Insert(table).(*insertBuilder)}
+}
+
+// Columns specifies the columns to insert
+func (ub *upsertBuilder) Columns(columns ...string) UpsertBuilder {
+	ub.insert.Columns(columns...)
+	return ub
+}
+
+// Values adds a set of values to insert
+func (ub *upsertBuilder) Values(values ...any) UpsertBuilder {
+	ub.insert.Values(values...)
+	return ub
+}
+
+// Key specifies the unique columns identifying an existing row
+func (ub *upsertBuilder) Key(columns ...string) UpsertBuilder {
+	ub.key = columns
+	return ub
+}
+
+// Update specifies the columns overwritten with the inserted values when
+// the row exists. Without them existing rows are left unchanged.
+func (ub *upsertBuilder) Update(columns ...string) UpsertBuilder {
+	ub.updates = append(ub.updates, columns...)
+	return ub
+}
+
+// Returning specifies columns to return after the upsert
+func (ub *upsertBuilder) Returning(columns ...string) UpsertBuilder {
+	ub.insert.Returning(columns...)
+	return ub
+}
+
+// ToSQL generates the SQL query and returns the query and parameters
+func (ub *upsertBuilder) ToSQL() (string, []any, error) {
+	if len(ub.key) == 0 {
+		return "", nil, errors.New("no key columns specified for upsert")
+	}
+	for _, col := range ub.updates {
+		if slices.Contains(ub.key, col) {
+			return "", nil, errors.New("upsert cannot update key column " + col)
+		}
+	}
+
+	conflict := ConflictAction{Target: strings.Join(ub.key, ", ")}
+	if len(ub.updates) == 0 {
+		conflict.DoNothing = true
+		// MySQL's no-op update assigns a single column to itself
+		if _, ok := ub.insert.dialect.(mysqlDialect); ok {
+			conflict.Target = ub.key[0]
+		}
+	} else {
+		conflict.DoUpdate = make(map[string]any, len(ub.updates))
+		for _, col := range ub.updates {
+			conflict.DoUpdate[col] = Excluded(col)
+		}
+	}
+
+	insert := *ub.insert
+	insert.conflict = &conflict
+	return insert.ToSQL()
+}
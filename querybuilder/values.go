@@ -0,0 +1,113 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// valuesList is a literal row set used as a relation in FROM or JOIN
+type valuesList struct {
+	rows    [][]any
+	alias   string
+	columns []string
+}
+
+// toSQL renders the row set as a derived table with its alias and column
+// names. Postgres and SQL Server use a VALUES table constructor, MySQL uses
+// VALUES ROW(...), and SQLite and Oracle fall back to SELECT ... UNION ALL.
+func (v *valuesList) toSQL(dialect Dialect, paramCount *int) (string, []any, error) {
+	if len(v.rows) == 0 {
+		return "", nil, errors.New("no rows specified for VALUES list")
+	}
+	if len(v.columns) == 0 {
+		return "", nil, errors.New("no columns specified for VALUES list")
+	}
+	if v.alias == "" {
+		return "", nil, errors.New("no alias specified for VALUES list")
+	}
+	for _, row := range v.rows {
+		if len(row) != len(v.columns) {
+			return "", nil, fmt.Errorf("number of values (%d) doesn't match columns (%d)",
+				len(row), len(v.columns))
+		}
+	}
+
+	var (
+		sql  strings.Builder
+		args []any
+	)
+
+	switch dialect.(type) {
+	case sqliteDialect, oracleDialect:
+		_, isOracle := dialect.(oracleDialect)
+		sql.WriteString("(")
+		for i, row := range v.rows {
+			if i > 0 {
+				sql.WriteString(" UNION ALL ")
+			}
+			sql.WriteString("SELECT ")
+			for j, val := range row {
+				if j > 0 {
+					sql.WriteString(", ")
+				}
+				valArgs, err := writeValue(&sql, val, dialect, paramCount)
+				if err != nil {
+					return "", nil, err
+				}
+				args = append(args, valArgs...)
+				if i == 0 {
+					sql.WriteString(" AS ")
+					sql.WriteString(v.columns[j])
+				}
+			}
+			if isOracle {
+				sql.WriteString(" FROM dual")
+			}
+		}
+		sql.WriteString(") ")
+		sql.WriteString(v.alias)
+	default:
+		_, isMySQL := dialect.(mysqlDialect)
+		sql.WriteString("(VALUES ")
+		for i, row := range v.rows {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			if isMySQL {
+				sql.WriteString("ROW")
+			}
+			sql.WriteString("(")
+			for j, val := range row {
+				if j > 0 {
+					sql.WriteString(", ")
+				}
+				valArgs, err := writeValue(&sql, val, dialect, paramCount)
+				if err != nil {
+					return "", nil, err
+				}
+				args = append(args, valArgs...)
+			}
+			sql.WriteString(")")
+		}
+		sql.WriteString(") AS ")
+		sql.WriteString(v.alias)
+		sql.WriteString("(")
+		sql.WriteString(strings.Join(v.columns, ", "))
+		sql.WriteString(")")
+	}
+
+	return sql.String(), args, nil
+}
+
+// appendValue writes a placeholder for the value and appends it to args.
+// Raw SQL values (Raw, UnsafeRaw) are written inline instead.
+func appendValue(sql *strings.Builder, val any, args []any, dialect Dialect, paramCount *int) []any {
+	if raw, ok := val.(rawSQL); ok {
+		sql.WriteString(raw.value)
+		return args
+	}
+	sql.WriteString(dialect.Placeholder(*paramCount))
+	*paramCount++
+	return append(args, val)
+}
@@ -0,0 +1,71 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrStaleRow is returned by Exec when a versioned update matches no row,
+// meaning the row was changed or deleted since it was read
+var ErrStaleRow = errors.New("stale row: version mismatch or row not found")
+
+// WithVersion adds optimistic locking on column: the update only matches
+// the row while column still equals current, and increments it by one
+func (ub *updateBuilder) WithVersion(column string, current any) UpdateBuilder {
+	ub.version = column
+	ub.Set(column, ExprValue(column+" + 1"))
+	return ub.Where(Eq(column, current))
+}
+
+// Exec runs the update. A versioned update that affects no row returns
+// ErrStaleRow.
+func (ub *updateBuilder) Exec(ctx context.Context, db Queryer) (sql.Result, error) {
+	query, args, err := ub.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if ub.version == "" {
+		return res, nil
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrStaleRow
+	}
+	return res, nil
+}
+
+// ExecReturning runs the update and scans the rows returned by its
+// RETURNING (OUTPUT on SQL Server) columns into dest, a pointer to a struct
+// or to a slice of structs. A versioned update scanning into a struct
+// returns ErrStaleRow when no row was updated.
+func (ub *updateBuilder) ExecReturning(ctx context.Context, db Queryer, dest any) error {
+	if len(ub.returning) == 0 {
+		return errors.New("ExecReturning requires Returning columns")
+	}
+	query, args, err := ub.ToSQL()
+	if err != nil {
+		return err
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	err = scanInto(rows, dest)
+	if errors.Is(err, sql.ErrNoRows) && ub.version != "" {
+		return ErrStaleRow
+	}
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,226 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConditionNode describes a condition visited by Walk
+type ConditionNode struct {
+	Condition Condition
+	Columns   []string    // columns the condition filters, if any
+	Operator  string      // e.g. "=", "IN", "BETWEEN", "EXISTS", "AND", "OR", "NOT"
+	Values    []any       // bound values, or the subquery of subquery conditions
+	SQL       string      // raw SQL of Expr and Fragment conditions
+	Children  []Condition // conditions nested in AND / OR / NOT and fragments
+	Depth     int
+}
+
+// HasColumn reports whether the node filters the given column
+func (n ConditionNode) HasColumn(column string) bool {
+	for _, c := range n.Columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk visits the condition tree depth first, calling fn for each
+// condition. Returning false from fn skips the children of that node.
+// Conditions defined outside this package are visited with only their
+// Condition field set.
+func Walk(condition Condition, fn func(node ConditionNode) bool) {
+	walk(condition, 0, fn)
+}
+
+// WalkConditions visits each condition of the list with Walk
+func WalkConditions(conditions []Condition, fn func(node ConditionNode) bool) {
+	for _, cond := range conditions {
+		walk(cond, 0, fn)
+	}
+}
+
+func walk(condition Condition, depth int, fn func(node ConditionNode) bool) {
+	if condition == nil {
+		return
+	}
+	node := describeCondition(condition)
+	node.Depth = depth
+	if !fn(node) {
+		return
+	}
+	for _, child := range node.Children {
+		walk(child, depth+1, fn)
+	}
+}
+
+// describeCondition builds the node of a single condition
+func describeCondition(condition Condition) ConditionNode {
+	node := ConditionNode{Condition: condition}
+	switch c := condition.(type) {
+	case *baseCondition:
+		node.Columns = []string{c.column}
+		node.Operator = string(c.operator)
+		if c.operator != IsNullOp && c.operator != IsNotNullOp {
+			node.Values = []any{c.value}
+		}
+	case *inCondition:
+		node.Columns = []string{c.column}
+		node.Operator = string(InOp)
+		if c.not {
+			node.Operator = string(NotInOp)
+		}
+		node.Values = flattenValues(c.values)
+	case *betweenCondition:
+		node.Columns = []string{c.column}
+		node.Operator = string(BetweenOp)
+		node.Values = []any{c.from, c.to}
+	case *ilikeCondition:
+		node.Columns = []string{c.column}
+		node.Operator = string(ILikeOp)
+		if c.not {
+			node.Operator = string(NotILikeOp)
+		}
+		node.Values = []any{c.pattern}
+	case *literalLikeCondition:
+		node.Columns = []string{c.column}
+		node.Operator = string(LikeOp)
+		node.Values = []any{c.prefix + c.value + c.suffix}
+	case *regexpCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "REGEXP"
+		if c.not {
+			node.Operator = "NOT REGEXP"
+		}
+		node.Values = []any{c.pattern}
+	case *arrayCondition:
+		node.Columns = []string{c.column}
+		node.Operator = c.operator
+		node.Values = []any{c.array}
+	case *columnCondition:
+		node.Columns = []string{c.left, c.right}
+		node.Operator = string(c.operator)
+	case *nullSafeEqCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "IS NOT DISTINCT FROM"
+		node.Values = []any{c.value}
+	case *bitAndCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "&"
+		node.Values = []any{c.mask, c.expected}
+	case *quantifiedCondition:
+		node.Columns = []string{c.column}
+		node.Operator = fmt.Sprintf("%s %s", c.operator, c.quantifier)
+		node.Values = []any{c.subquery}
+	case *existsCondition:
+		node.Operator = "EXISTS"
+		if c.not {
+			node.Operator = "NOT EXISTS"
+		}
+		node.Values = []any{c.subquery}
+	case *seekCondition:
+		node.Columns = c.columns
+		node.Operator = string(c.operator)
+		node.Values = c.values
+	case *dateRangeCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "DATE RANGE"
+		node.Values = []any{c.from, c.to}
+	case *withinLastCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "WITHIN LAST"
+		node.Values = []any{c.seconds}
+	case *spatialCondition:
+		node.Columns = []string{c.column}
+		node.Operator = "ST_" + c.function
+		node.Values = []any{c.geometry}
+	case *collatedCondition:
+		node.Operator = "COLLATE"
+		node.Values = []any{c.collation}
+		node.Children = []Condition{c.condition}
+	case *logicalCondition:
+		node.Operator = c.operator
+		node.Children = c.conditions
+	case *notCondition:
+		node.Operator = "NOT"
+		node.Children = []Condition{c.condition}
+	case *exprCondition:
+		node.Operator = "EXPR"
+		node.SQL = c.sql
+		node.Values = c.args
+	case Fragment:
+		node.Operator = "FRAGMENT"
+		node.SQL = c.sql
+		for _, arg := range c.args {
+			if child, ok := arg.(Condition); ok {
+				node.Children = append(node.Children, child)
+			} else {
+				node.Values = append(node.Values, arg)
+			}
+		}
+	}
+	return node
+}
+
+// Rewrite returns the condition tree with fn applied to every condition,
+// children first, so middleware can replace predicates (e.g. narrow a
+// tenant filter). fn returns node.Condition to keep a condition, another
+// condition to replace it and nil to remove it: removed conditions are
+// dropped from AND / OR groups, and NOT, COLLATE and fragments lose their
+// whole node. The tree passed in is not modified.
+func Rewrite(condition Condition, fn func(node ConditionNode) Condition) Condition {
+	return rewrite(condition, 0, fn)
+}
+
+// RewriteConditions rewrites each condition of the list with Rewrite,
+// leaving out those that are removed
+func RewriteConditions(conditions []Condition, fn func(node ConditionNode) Condition) []Condition {
+	return rewriteList(conditions, 0, fn)
+}
+
+func rewriteList(conditions []Condition, depth int, fn func(node ConditionNode) Condition) []Condition {
+	rewritten := make([]Condition, 0, len(conditions))
+	for _, cond := range conditions {
+		if cond = rewrite(cond, depth, fn); cond != nil {
+			rewritten = append(rewritten, cond)
+		}
+	}
+	return rewritten
+}
+
+func rewrite(condition Condition, depth int, fn func(node ConditionNode) Condition) Condition {
+	if condition == nil {
+		return nil
+	}
+	switch c := condition.(type) {
+	case *logicalCondition:
+		condition = &logicalCondition{operator: c.operator, conditions: rewriteList(c.conditions, depth+1, fn)}
+	case *notCondition:
+		child := rewrite(c.condition, depth+1, fn)
+		if child == nil {
+			return nil
+		}
+		condition = &notCondition{condition: child}
+	case *collatedCondition:
+		child := rewrite(c.condition, depth+1, fn)
+		if child == nil {
+			return nil
+		}
+		condition = &collatedCondition{condition: child, collation: c.collation}
+	case Fragment:
+		args := make([]any, len(c.args))
+		for i, arg := range c.args {
+			if child, ok := arg.(Condition); ok {
+				if arg = rewrite(child, depth+1, fn); arg == nil {
+					return nil
+				}
+			}
+			args[i] = arg
+		}
+		condition = Fragment{sql: c.sql, args: args}
+	}
+	node := describeCondition(condition)
+	node.Depth = depth
+	return fn(node)
+}
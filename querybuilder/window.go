@@ -0,0 +1,155 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WindowBuilder interface for constructing window function expressions
+type WindowBuilder interface {
+	PartitionBy(columns ...string) WindowBuilder
+	OrderBy(column string, direction string) WindowBuilder
+	Rows(start, end FrameBound) WindowBuilder
+	Range(start, end FrameBound) WindowBuilder
+	As(alias string) WindowBuilder
+	ToSQL() (string, []any, error)
+}
+
+// FrameBound represents a boundary of a window frame clause
+type FrameBound string
+
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding creates an "n PRECEDING" frame bound
+func Preceding(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d PRECEDING", n))
+}
+
+// Following creates an "n FOLLOWING" frame bound
+func Following(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d FOLLOWING", n))
+}
+
+// windowFrame holds the frame clause of a window
+type windowFrame struct {
+	unit  string // "ROWS", "RANGE"
+	start FrameBound
+	end   FrameBound
+}
+
+// windowBuilder implements WindowBuilder
+type windowBuilder struct {
+	function    string
+	partitionBy []string
+	orderBy     []order
+	frame       *windowFrame
+	alias       string
+}
+
+// Window begins a window function expression such as ROW_NUMBER() OVER (...)
+func Window(function string) WindowBuilder {
+	return &windowBuilder{
+		function: function,
+	}
+}
+
+// RowNumber begins a ROW_NUMBER() window expression
+func RowNumber() WindowBuilder {
+	return Window("ROW_NUMBER()")
+}
+
+// Rank begins a RANK() window expression
+func Rank() WindowBuilder {
+	return Window("RANK()")
+}
+
+// DenseRank begins a DENSE_RANK() window expression
+func DenseRank() WindowBuilder {
+	return Window("DENSE_RANK()")
+}
+
+// PartitionBy adds PARTITION BY columns
+func (wb *windowBuilder) PartitionBy(columns ...string) WindowBuilder {
+	wb.partitionBy = append(wb.partitionBy, columns...)
+	return wb
+}
+
+// OrderBy adds ORDER BY clause inside the window
+func (wb *windowBuilder) OrderBy(column string, direction string) WindowBuilder {
+	if direction != "ASC" && direction != "DESC" {
+		direction = "ASC"
+	}
+	wb.orderBy = append(wb.orderBy, order{
+		column:    column,
+		direction: direction,
+	})
+	return wb
+}
+
+// Rows sets a ROWS BETWEEN frame clause
+func (wb *windowBuilder) Rows(start, end FrameBound) WindowBuilder {
+	wb.frame = &windowFrame{unit: "ROWS", start: start, end: end}
+	return wb
+}
+
+// Range sets a RANGE BETWEEN frame clause
+func (wb *windowBuilder) Range(start, end FrameBound) WindowBuilder {
+	wb.frame = &windowFrame{unit: "RANGE", start: start, end: end}
+	return wb
+}
+
+// As sets the alias of the window expression
+func (wb *windowBuilder) As(alias string) WindowBuilder {
+	wb.alias = alias
+	return wb
+}
+
+// ToSQL generates the window expression
+func (wb *windowBuilder) ToSQL() (string, []any, error) {
+	if wb.function == "" {
+		return "", nil, errors.New("no function specified for window expression")
+	}
+	if wb.frame != nil && len(wb.orderBy) == 0 {
+		return "", nil, errors.New("window frame requires an ORDER BY clause")
+	}
+
+	var query strings.Builder
+
+	query.WriteString(wb.function)
+	query.WriteString(" OVER (")
+
+	clauses := make([]string, 0, 3)
+	if len(wb.partitionBy) > 0 {
+		clauses = append(clauses, "PARTITION BY "+strings.Join(wb.partitionBy, ", "))
+	}
+	if len(wb.orderBy) > 0 {
+		var orderBy strings.Builder
+		orderBy.WriteString("ORDER BY ")
+		for i, ob := range wb.orderBy {
+			if i > 0 {
+				orderBy.WriteString(", ")
+			}
+			orderBy.WriteString(ob.column)
+			orderBy.WriteString(" ")
+			orderBy.WriteString(ob.direction)
+		}
+		clauses = append(clauses, orderBy.String())
+	}
+	if wb.frame != nil {
+		clauses = append(clauses, fmt.Sprintf("%s BETWEEN %s AND %s", wb.frame.unit, wb.frame.start, wb.frame.end))
+	}
+	query.WriteString(strings.Join(clauses, " "))
+	query.WriteString(")")
+
+	if wb.alias != "" {
+		query.WriteString(" AS ")
+		query.WriteString(wb.alias)
+	}
+
+	return query.String(), nil, nil
+}